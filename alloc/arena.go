@@ -18,6 +18,9 @@ type (
 	MutexArena = arena.MutexArena
 )
 
+// ArenaMark is an arena checkpoint, see [Arena.Mark] and [Arena.ResetTo].
+type ArenaMark = arena.Mark
+
 // NewArena returns a new non-thread-safe arena.
 func NewArena() Arena {
 	return arena.New()
@@ -33,6 +36,23 @@ func NewMutexArena() Arena {
 	return arena.NewMutexArena()
 }
 
+// MmapOptions specifies the mmap arena options.
+type MmapOptions = arena.MmapOptions
+
+// DefaultMmapOptions returns the default mmap arena options.
+func DefaultMmapOptions() MmapOptions {
+	return arena.DefaultMmapOptions()
+}
+
+// NewMmapArena returns a new non-thread-safe arena backed by anonymous mmap regions.
+//
+// The arena memory is invisible to the garbage collector, so the arena must not be used
+// to store pointers to the Go heap, use Pin to keep external objects alive.
+// The regions are reused on Reset and unmapped on Free.
+func NewMmapArena(opts MmapOptions) Arena {
+	return arena.NewMmapArena(opts)
+}
+
 // AcquireArena returns a pooled arena, which is released to the pool on Free.
 //
 // The arena must not be used or even referenced after Free.
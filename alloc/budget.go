@@ -0,0 +1,43 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package alloc
+
+import "github.com/basecomplextech/baselibrary/alloc/internal/heap"
+
+type (
+	// Budget specifies a process-wide memory budget for arenas, buffers and byte queues.
+	//
+	// Allocations which exceed the hard limit fail with an unavailable status:
+	// Arena.TryAlloc and Arena.TryBytes return it, buffer writes return it as an error,
+	// byte queue writes return it. Methods which cannot return a status, i.e. Arena.Alloc
+	// and Buffer.Grow, panic.
+	Budget = heap.Budget
+
+	// Stats contains process-wide memory counters of arenas, buffers and byte queues.
+	Stats = heap.Stats
+
+	// ClassStats contains memory counters of a block size class.
+	ClassStats = heap.ClassStats
+)
+
+// SetBudget sets the process-wide memory budget, the zero budget means unlimited.
+//
+// Example:
+//
+//	overloaded := async.UnsetFlag()
+//
+//	alloc.SetBudget(alloc.Budget{
+//		SoftLimit:   1 << 30,
+//		HardLimit:   2 << 30,
+//		OnSoftLimit: overloaded.SetTo,
+//	})
+func SetBudget(b Budget) {
+	heap.Global.SetBudget(b)
+}
+
+// ReadStats returns the process-wide memory counters.
+func ReadStats() Stats {
+	return heap.Global.Stats()
+}
@@ -34,3 +34,17 @@ func NewBufferSize(size int) Buffer {
 func AcquireBuffer() Buffer {
 	return buffer.Acquire()
 }
+
+// Rope is a chunked buffer which never merges its chunks on writes,
+// and allows to read and write them without copying.
+type Rope = buffer.Rope
+
+// NewRope allocates a rope buffer.
+func NewRope() Rope {
+	return buffer.NewRope()
+}
+
+// NewRopeSize allocates a rope buffer of a preallocated capacity.
+func NewRopeSize(size int) Rope {
+	return buffer.NewRopeSize(size)
+}
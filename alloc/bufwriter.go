@@ -22,3 +22,11 @@ func NewBufferedWriter(dst io.Writer) BufferedWriter {
 func NewBufferedWriterSize(dst io.Writer, size int) BufferedWriter {
 	return bufwriter.NewSize(dst, size)
 }
+
+// BufferedWriterOptions specifies buffered writer options.
+type BufferedWriterOptions = bufwriter.Options
+
+// NewBufferedWriterOptions returns a new buffered writer with the specified options.
+func NewBufferedWriterOptions(dst io.Writer, opts BufferedWriterOptions) BufferedWriter {
+	return bufwriter.NewOptions(dst, opts)
+}
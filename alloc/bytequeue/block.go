@@ -78,6 +78,23 @@ func (b *block) copy(msg []byte) int32 {
 	return wi
 }
 
+// reserve
+
+// reserve returns a buffer for a message at the start index.
+// the method is called by a single writer inside the write lock.
+func (b *block) reserve(start int32, size int) []byte {
+	p := b.b.Bytes()
+	return p[start+4 : int(start)+4+size : int(start)+4+size]
+}
+
+// commit writes the message size, and returns the next write index.
+// the method is called by a single writer inside the write lock.
+func (b *block) commit(start int32, size int32) int32 {
+	p := b.b.Bytes()
+	binary.BigEndian.PutUint32(p[start:], uint32(size))
+	return start + 4 + size
+}
+
 // guarded by queue.mu
 
 // cap returns the block capacity.
@@ -0,0 +1,602 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package bytequeue
+
+import (
+	"encoding/binary"
+	"math"
+	"sync"
+
+	"github.com/basecomplextech/baselibrary/alloc/internal/heap"
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// Fanout is a multiple writers, multiple consumers binary message queue.
+//
+// Each consumer has its own read cursor and receives all messages written after it has been added.
+// Blocks are released only after all consumers have read them.
+//
+// The queue can be unbounded, or can be configured with a soft max capacity.
+// In the latter case, slow consumers either block writers, or are dropped, or disconnected,
+// see [SlowPolicy].
+type Fanout interface {
+	// Closed returns true if the queue is closed.
+	Closed() bool
+
+	// Methods
+
+	// Close closes the queue for writing, consumers can still read pending messages.
+	Close()
+
+	// Consumer adds a new consumer which receives all messages written after this call,
+	// or returns an end if the queue is closed.
+	Consumer() (Consumer, status.Status)
+
+	// Write
+
+	// Write writes a message to all consumers, returns false if full, or an end if closed.
+	// The method returns an unavailable status if the alloc memory budget is exceeded.
+	// The message is discarded if there are no consumers.
+	Write(msg []byte) (bool, status.Status)
+
+	// WriteWait returns a channel which is notified when a message can be written.
+	// The method returns a closed channel if the queue is closed.
+	WriteWait(size int) <-chan struct{}
+
+	// Internal
+
+	// Free disconnects all consumers and releases the queue and its internal resources.
+	Free()
+}
+
+// Consumer is a fanout queue consumer, it must be used by a single reader.
+// The consumer must be freed after usage.
+type Consumer interface {
+	// Lag returns the number of unread bytes.
+	Lag() int
+
+	// Dropped returns the number of bytes dropped by the [SlowDrop] policy.
+	Dropped() int
+
+	// Read reads a message, the message is valid until the next call to read.
+	// The method returns an end status when there are no more messages and the queue is closed,
+	// or a closed status when the consumer has been disconnected by the [SlowDisconnect] policy.
+	Read() ([]byte, bool, status.Status)
+
+	// ReadWait returns a channel which is notified when more messages are available.
+	// The method returns a closed channel if the queue is closed or the consumer is disconnected.
+	ReadWait() <-chan struct{}
+
+	// Internal
+
+	// Free removes the consumer from the queue.
+	Free()
+}
+
+// SlowPolicy specifies how a fanout queue handles slow consumers when it is full.
+type SlowPolicy int
+
+const (
+	// SlowBlock blocks writers until the slowest consumer reads its messages.
+	SlowBlock SlowPolicy = iota
+
+	// SlowDrop drops pending messages of the slowest consumers.
+	SlowDrop
+
+	// SlowDisconnect disconnects the slowest consumers.
+	SlowDisconnect
+)
+
+// FanoutOptions specifies the fanout queue options.
+type FanoutOptions struct {
+	// Cap is the soft max capacity of the queue, 0 means unlimited.
+	Cap int
+
+	// Slow specifies how the queue handles slow consumers when it is full.
+	Slow SlowPolicy
+}
+
+// NewFanout allocates a fanout queue.
+func NewFanout(opts FanoutOptions) Fanout {
+	return newFanout(heap.Global, opts)
+}
+
+// internal
+
+var _ Fanout = (*fanout)(nil)
+
+type fanout struct {
+	opts FanoutOptions
+	heap *heap.Heap
+
+	// channel for writers to wait on
+	writeChan chan struct{}
+
+	mu        sync.Mutex
+	closed    bool
+	pos       int64 // write position
+	head      *fanoutBlock
+	tail      *fanoutBlock
+	consumers map[*consumer]struct{}
+}
+
+type fanoutBlock struct {
+	b       *heap.Block
+	start   int64 // stream position of the block start
+	len     int   // written bytes
+	next    *fanoutBlock
+	holds   int  // number of consumers which hold the last read messages in the block
+	removed bool // block is removed from the queue, but can still be held
+}
+
+func newFanout(h *heap.Heap, opts FanoutOptions) *fanout {
+	return &fanout{
+		opts: opts,
+		heap: h,
+
+		writeChan: make(chan struct{}, 1),
+		consumers: make(map[*consumer]struct{}),
+	}
+}
+
+// Closed returns true if the queue is closed.
+func (q *fanout) Closed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.closed
+}
+
+// Close closes the queue for writing, consumers can still read pending messages.
+func (q *fanout) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+
+	q.closed = true
+	q.notifyReadAll()
+	q.notifyWriteAll()
+}
+
+// Consumer adds a new consumer which receives all messages written after this call,
+// or returns an end if the queue is closed.
+func (q *fanout) Consumer() (Consumer, status.Status) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil, status.End
+	}
+
+	c := newConsumer(q)
+	q.consumers[c] = struct{}{}
+	return c, status.OK
+}
+
+// Write writes a message to all consumers, returns false if full, or an end if closed.
+func (q *fanout) Write(msg []byte) (bool, status.Status) {
+	if len(msg) > math.MaxInt32 {
+		panic("message too large")
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return false, status.End
+	}
+	if len(q.consumers) == 0 {
+		return true, status.OK
+	}
+
+	// Get a block to write to.
+	n := 4 + len(msg)
+	block, ok, st := q.writeBlock(n)
+	switch {
+	case !st.OK():
+		return false, st
+	case !ok:
+		return false, status.OK
+	}
+
+	// Write message to the block.
+	p := block.b.Bytes()[block.len : block.len+n]
+	binary.BigEndian.PutUint32(p, uint32(len(msg)))
+	copy(p[4:], msg)
+
+	block.len += n
+	q.pos += int64(n)
+
+	q.notifyReadAll()
+	return true, status.OK
+}
+
+// WriteWait returns a channel which is notified when a message can be written.
+// The method returns a closed channel if the queue is closed.
+func (q *fanout) WriteWait(size int) <-chan struct{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || len(q.consumers) == 0 {
+		return closedChan
+	}
+
+	// Return closed chan on error, the next write returns the error.
+	_, ok, st := q.writeBlock(4 + size)
+	if ok || !st.OK() {
+		return closedChan
+	}
+
+	select {
+	case <-q.writeChan:
+	default:
+	}
+
+	return q.writeChan
+}
+
+// Free disconnects all consumers and releases the queue and its internal resources.
+func (q *fanout) Free() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for c := range q.consumers {
+		c.disconnected = true
+		c.block = nil
+		c.notifyRead()
+		delete(q.consumers, c)
+	}
+
+	q.closed = true
+	q.notifyWriteAll()
+
+	for b := q.head; b != nil; b = b.next {
+		q.removeBlock(b)
+	}
+	q.head = nil
+	q.tail = nil
+}
+
+// private
+
+// writeBlock returns or allocates a block with n free bytes.
+func (q *fanout) writeBlock(n int) (*fanoutBlock, bool, status.Status) {
+	// Check queue is not full. Messages can be larger than the queue max capacity.
+	// In this case we write them only if the queue is empty.
+	if q.opts.Cap > 0 {
+		for {
+			occupied := int(q.pos - q.minPos())
+			if occupied == 0 || occupied+n <= q.opts.Cap {
+				break
+			}
+			if q.opts.Slow == SlowBlock {
+				return nil, false, status.OK
+			}
+
+			q.handleSlow()
+		}
+	}
+
+	// Return tail if it has enough free space.
+	tail := q.tail
+	if tail != nil && tail.b.Cap()-tail.len >= n {
+		return tail, true, status.OK
+	}
+
+	// Allocate a new block.
+	block, st := q.alloc(n)
+	if !st.OK() {
+		return nil, false, st
+	}
+	return block, true, status.OK
+}
+
+// alloc allocates a new block, or returns an error if the memory budget is exceeded.
+func (q *fanout) alloc(n int) (*fanoutBlock, status.Status) {
+	size := 0
+
+	// Double tail block capacity if possible,
+	// but no more than 1/4 of the queue capacity.
+	if q.tail != nil {
+		size = q.tail.b.Cap() * 2
+
+		if q.opts.Cap > 0 {
+			max := q.opts.Cap / 4
+			if size > max {
+				size = max
+			}
+		}
+		if size > maxBlockSize {
+			size = maxBlockSize
+		}
+	}
+
+	// Use the requested size if larger.
+	if n > size {
+		size = n
+	}
+
+	// Allocate new block.
+	b, st := q.heap.TryAlloc(size)
+	if !st.OK() {
+		return nil, st
+	}
+	b.Grow(b.Cap()) // use all available space
+
+	block := &fanoutBlock{
+		b:     b,
+		start: q.pos,
+	}
+	if q.tail == nil {
+		q.head = block
+	} else {
+		q.tail.next = block
+	}
+	q.tail = block
+	return block, status.OK
+}
+
+// handleSlow drops or disconnects the slowest consumers.
+func (q *fanout) handleSlow() {
+	min := q.minPos()
+
+	for c := range q.consumers {
+		if c.pos != min {
+			continue
+		}
+
+		switch q.opts.Slow {
+		case SlowDrop:
+			c.dropped += q.pos - c.pos
+			c.pos = q.pos
+			c.block = q.tail
+
+		case SlowDisconnect:
+			c.disconnected = true
+			c.block = nil
+			delete(q.consumers, c)
+			c.notifyRead()
+		}
+	}
+
+	q.releaseBlocks()
+}
+
+// minPos returns the min consumer position.
+func (q *fanout) minPos() int64 {
+	min := q.pos
+	for c := range q.consumers {
+		if c.pos < min {
+			min = c.pos
+		}
+	}
+	return min
+}
+
+// releaseBlocks removes head blocks which have been read by all consumers.
+func (q *fanout) releaseBlocks() {
+	min := q.minPos()
+
+	for q.head != nil && q.head != q.tail {
+		head := q.head
+		end := head.start + int64(head.len)
+		if min < end {
+			break
+		}
+
+		// Move consumers to the next block
+		for c := range q.consumers {
+			if c.block == head {
+				c.block = head.next
+			}
+		}
+
+		q.head = head.next
+		q.removeBlock(head)
+	}
+
+	q.notifyWrite()
+}
+
+// removeBlock frees a block if it is not held by consumers.
+func (q *fanout) removeBlock(b *fanoutBlock) {
+	b.removed = true
+	if b.holds > 0 {
+		return
+	}
+
+	q.heap.Free(b.b)
+	b.b = nil
+}
+
+// unhold releases a block held by a consumer.
+func (q *fanout) unhold(b *fanoutBlock) {
+	b.holds--
+	if b.holds == 0 && b.removed {
+		q.heap.Free(b.b)
+		b.b = nil
+	}
+}
+
+// notify
+
+func (q *fanout) notifyReadAll() {
+	for c := range q.consumers {
+		c.notifyRead()
+	}
+}
+
+func (q *fanout) notifyWrite() {
+	select {
+	case q.writeChan <- struct{}{}:
+	default:
+	}
+}
+
+func (q *fanout) notifyWriteAll() {
+	for {
+		select {
+		case q.writeChan <- struct{}{}:
+		default:
+			return
+		}
+	}
+}
+
+// consumer
+
+var _ Consumer = (*consumer)(nil)
+
+type consumer struct {
+	q        *fanout
+	readChan chan struct{}
+
+	// guarded by q.mu
+	pos          int64        // read position
+	block        *fanoutBlock // block at the read position, nil when not allocated yet
+	held         *fanoutBlock // block with the last read message
+	dropped      int64
+	disconnected bool
+	freed        bool
+}
+
+func newConsumer(q *fanout) *consumer {
+	return &consumer{
+		q:        q,
+		readChan: make(chan struct{}, 1),
+
+		pos:   q.pos,
+		block: q.tail,
+	}
+}
+
+// Lag returns the number of unread bytes.
+func (c *consumer) Lag() int {
+	c.q.mu.Lock()
+	defer c.q.mu.Unlock()
+
+	if c.disconnected {
+		return 0
+	}
+	return int(c.q.pos - c.pos)
+}
+
+// Dropped returns the number of bytes dropped by the [SlowDrop] policy.
+func (c *consumer) Dropped() int {
+	c.q.mu.Lock()
+	defer c.q.mu.Unlock()
+
+	return int(c.dropped)
+}
+
+// Read reads a message, the message is valid until the next call to read.
+func (c *consumer) Read() ([]byte, bool, status.Status) {
+	q := c.q
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// Release previous message
+	c.unhold()
+
+	switch {
+	case c.freed:
+		return nil, false, status.Closedf("consumer freed")
+	case c.disconnected:
+		return nil, false, status.Closedf("slow consumer disconnected")
+	}
+
+	// Check pending messages
+	if c.pos == q.pos {
+		if q.closed {
+			return nil, false, status.End
+		}
+		return nil, false, status.OK
+	}
+
+	// Find block at position
+	block := c.block
+	if block == nil {
+		block = q.head
+	}
+	for c.pos == block.start+int64(block.len) {
+		block = block.next
+	}
+	c.block = block
+
+	// Read message
+	p := block.b.Bytes()[c.pos-block.start:]
+	size := binary.BigEndian.Uint32(p)
+	msg := p[4 : 4+size]
+
+	c.pos += 4 + int64(size)
+	c.held = block
+	block.holds++
+
+	// Release head blocks when passed, or just notify writers
+	if head := q.head; head != q.tail && c.pos >= head.start+int64(head.len) {
+		q.releaseBlocks()
+	} else if q.opts.Cap > 0 {
+		q.notifyWrite()
+	}
+	return msg, true, status.OK
+}
+
+// ReadWait returns a channel which is notified when more messages are available.
+// The method returns a closed channel if the queue is closed or the consumer is disconnected.
+func (c *consumer) ReadWait() <-chan struct{} {
+	q := c.q
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if c.disconnected || c.freed || q.closed || c.pos < q.pos {
+		return closedChan
+	}
+
+	select {
+	case <-c.readChan:
+	default:
+	}
+
+	return c.readChan
+}
+
+// Free removes the consumer from the queue.
+func (c *consumer) Free() {
+	q := c.q
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if c.freed {
+		return
+	}
+	c.freed = true
+
+	c.unhold()
+	delete(q.consumers, c)
+	c.block = nil
+
+	q.releaseBlocks()
+}
+
+// private
+
+func (c *consumer) unhold() {
+	if c.held == nil {
+		return
+	}
+
+	c.q.unhold(c.held)
+	c.held = nil
+}
+
+func (c *consumer) notifyRead() {
+	select {
+	case c.readChan <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package bytequeue
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/alloc/internal/heap"
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFanout(opts FanoutOptions) *fanout {
+	h := heap.New()
+	return newFanout(h, opts)
+}
+
+func testConsumer(t *testing.T, q *fanout) *consumer {
+	t.Helper()
+
+	c, st := q.Consumer()
+	if !st.OK() {
+		t.Fatal(st)
+	}
+	return c.(*consumer)
+}
+
+func testFanoutWrite(t *testing.T, q *fanout, msg []byte) {
+	t.Helper()
+
+	ok, st := q.Write(msg)
+	if !st.OK() {
+		t.Fatal(st)
+	}
+	if !ok {
+		t.Fatal("write failed")
+	}
+}
+
+func testConsumerRead(t *testing.T, c *consumer) []byte {
+	t.Helper()
+
+	msg, ok, st := c.Read()
+	if !st.OK() {
+		t.Fatal(st)
+	}
+	if !ok {
+		t.Fatal("read failed")
+	}
+	return msg
+}
+
+// Write
+
+func TestFanout_Write__should_deliver_message_to_all_consumers(t *testing.T) {
+	q := testFanout(FanoutOptions{})
+	c0 := testConsumer(t, q)
+	c1 := testConsumer(t, q)
+
+	testFanoutWrite(t, q, []byte("hello"))
+	testFanoutWrite(t, q, []byte("world"))
+
+	assert.Equal(t, "hello", string(testConsumerRead(t, c0)))
+	assert.Equal(t, "world", string(testConsumerRead(t, c0)))
+	assert.Equal(t, "hello", string(testConsumerRead(t, c1)))
+	assert.Equal(t, "world", string(testConsumerRead(t, c1)))
+
+	_, ok, st := c0.Read()
+	assert.False(t, ok)
+	assert.True(t, st.OK())
+}
+
+func TestFanout_Write__should_discard_message_when_no_consumers(t *testing.T) {
+	q := testFanout(FanoutOptions{})
+	testFanoutWrite(t, q, []byte("hello"))
+
+	c := testConsumer(t, q)
+	_, ok, _ := c.Read()
+	assert.False(t, ok)
+	assert.Nil(t, q.head)
+}
+
+func TestFanout_Write__should_return_false_when_full_and_slow_block(t *testing.T) {
+	q := testFanout(FanoutOptions{Cap: 1024, Slow: SlowBlock})
+	c := testConsumer(t, q)
+
+	msg := bytes.Repeat([]byte("a"), 512-4)
+	testFanoutWrite(t, q, msg)
+	testFanoutWrite(t, q, msg)
+
+	ok, st := q.Write(msg)
+	require.True(t, st.OK())
+	assert.False(t, ok)
+
+	testConsumerRead(t, c)
+	testConsumerRead(t, c)
+
+	select {
+	case <-q.WriteWait(len(msg)):
+	default:
+		t.Fatal("should be writable")
+	}
+}
+
+func TestFanout_Write__should_drop_messages_of_slow_consumer(t *testing.T) {
+	q := testFanout(FanoutOptions{Cap: 1024, Slow: SlowDrop})
+	c0 := testConsumer(t, q)
+	c1 := testConsumer(t, q)
+
+	msg := bytes.Repeat([]byte("a"), 512-4)
+	testFanoutWrite(t, q, msg)
+	testFanoutWrite(t, q, msg)
+	testConsumerRead(t, c0)
+	testConsumerRead(t, c0)
+
+	testFanoutWrite(t, q, []byte("hello"))
+	assert.Equal(t, 1024, c1.Dropped())
+	assert.Equal(t, "hello", string(testConsumerRead(t, c1)))
+	assert.Equal(t, "hello", string(testConsumerRead(t, c0)))
+}
+
+func TestFanout_Write__should_disconnect_slow_consumer(t *testing.T) {
+	q := testFanout(FanoutOptions{Cap: 1024, Slow: SlowDisconnect})
+	c0 := testConsumer(t, q)
+	c1 := testConsumer(t, q)
+
+	msg := bytes.Repeat([]byte("a"), 512-4)
+	testFanoutWrite(t, q, msg)
+	testFanoutWrite(t, q, msg)
+	testConsumerRead(t, c0)
+	testConsumerRead(t, c0)
+
+	testFanoutWrite(t, q, []byte("hello"))
+	assert.Len(t, q.consumers, 1)
+
+	_, _, st := c1.Read()
+	assert.Equal(t, status.CodeClosed, st.Code)
+	assert.Equal(t, "hello", string(testConsumerRead(t, c0)))
+}
+
+// Read
+
+func TestConsumer_Read__should_release_blocks_after_all_consumers(t *testing.T) {
+	q := testFanout(FanoutOptions{})
+	c0 := testConsumer(t, q)
+	c1 := testConsumer(t, q)
+
+	msg := bytes.Repeat([]byte("a"), 1024-4)
+	for i := 0; i < 4; i++ {
+		testFanoutWrite(t, q, msg)
+	}
+	head := q.head
+
+	for i := 0; i < 4; i++ {
+		testConsumerRead(t, c0)
+	}
+	assert.Same(t, head, q.head)
+
+	for i := 0; i < 4; i++ {
+		testConsumerRead(t, c1)
+	}
+	assert.Same(t, q.tail, q.head)
+}
+
+func TestConsumer_Read__should_keep_last_message_valid_until_next_read(t *testing.T) {
+	q := testFanout(FanoutOptions{})
+	c := testConsumer(t, q)
+
+	msg := bytes.Repeat([]byte("a"), 1024-4)
+	testFanoutWrite(t, q, msg)
+	testFanoutWrite(t, q, msg)
+
+	msg0 := testConsumerRead(t, c)
+	msg1 := testConsumerRead(t, c)
+	assert.Equal(t, msg, msg1)
+	_ = msg0
+
+	head := q.head
+	assert.NotNil(t, head.b)
+}
+
+func TestConsumer_Read__should_return_end_when_closed(t *testing.T) {
+	q := testFanout(FanoutOptions{})
+	c := testConsumer(t, q)
+
+	testFanoutWrite(t, q, []byte("hello"))
+	q.Close()
+
+	testConsumerRead(t, c)
+	_, ok, st := c.Read()
+	assert.False(t, ok)
+	assert.Equal(t, status.End, st)
+}
+
+// Lag
+
+func TestConsumer_Lag__should_return_unread_bytes(t *testing.T) {
+	q := testFanout(FanoutOptions{})
+	c0 := testConsumer(t, q)
+	c1 := testConsumer(t, q)
+
+	testFanoutWrite(t, q, []byte("hello"))
+	testFanoutWrite(t, q, []byte("world"))
+	testConsumerRead(t, c0)
+
+	assert.Equal(t, 9, c0.Lag())
+	assert.Equal(t, 18, c1.Lag())
+}
+
+// Free
+
+func TestConsumer_Free__should_release_blocks(t *testing.T) {
+	q := testFanout(FanoutOptions{})
+	c0 := testConsumer(t, q)
+	c1 := testConsumer(t, q)
+
+	msg := bytes.Repeat([]byte("a"), 1024-4)
+	for i := 0; i < 4; i++ {
+		testFanoutWrite(t, q, msg)
+	}
+	for i := 0; i < 4; i++ {
+		testConsumerRead(t, c0)
+	}
+
+	c1.Free()
+	assert.Same(t, q.tail, q.head)
+}
+
+func TestFanout_Free__should_disconnect_consumers(t *testing.T) {
+	q := testFanout(FanoutOptions{})
+	c := testConsumer(t, q)
+	testFanoutWrite(t, q, []byte("hello"))
+
+	q.Free()
+
+	_, _, st := c.Read()
+	assert.Equal(t, status.CodeClosed, st.Code)
+	assert.Nil(t, q.head)
+}
+
+// Concurrency
+
+func TestFanout__should_deliver_messages_concurrently(t *testing.T) {
+	q := testFanout(FanoutOptions{Cap: 4096})
+	n := 1000
+
+	consumers := make([]*consumer, 4)
+	for i := range consumers {
+		consumers[i] = testConsumer(t, q)
+	}
+
+	var wg sync.WaitGroup
+	for _, c := range consumers {
+		wg.Add(1)
+		go func(c *consumer) {
+			defer wg.Done()
+
+			for i := 0; ; {
+				msg, ok, st := c.Read()
+				switch {
+				case st == status.End:
+					if i != n {
+						t.Errorf("expected %d messages, got %d", n, i)
+					}
+					return
+				case !st.OK():
+					t.Error(st)
+					return
+				case !ok:
+					<-c.ReadWait()
+					continue
+				}
+
+				if string(msg) != fmt.Sprint(i) {
+					t.Errorf("expected %d, got %s", i, msg)
+					return
+				}
+				i++
+			}
+		}(c)
+	}
+
+	for i := 0; i < n; {
+		msg := []byte(fmt.Sprint(i))
+		ok, st := q.Write(msg)
+		if !st.OK() {
+			t.Fatal(st)
+		}
+		if !ok {
+			<-q.WriteWait(len(msg))
+			continue
+		}
+		i++
+	}
+	q.Close()
+
+	wg.Wait()
+}
+
+func TestFanout_WriteWait__should_notify_writer_when_consumer_reads_from_tail(t *testing.T) {
+	q := testFanout(FanoutOptions{Cap: 256})
+	c := testConsumer(t, q)
+
+	msg := bytes.Repeat([]byte("a"), 128-4)
+	testFanoutWrite(t, q, msg)
+	testFanoutWrite(t, q, msg)
+
+	wait := q.WriteWait(len(msg))
+	testConsumerRead(t, c)
+
+	select {
+	case <-wait:
+	default:
+		t.Fatal("should notify writer")
+	}
+}
@@ -6,6 +6,7 @@ package bytequeue
 
 import (
 	"math"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"unsafe"
@@ -37,19 +38,49 @@ type Queue interface {
 	// The method returns an end status when there are no more items and the queue is closed.
 	Read() ([]byte, bool, status.Status)
 
+	// ReadBatch reads up to max contiguous messages from the queue, the messages are valid
+	// until the next call to read. The method returns an end status when there are no more items
+	// and the queue is closed.
+	ReadBatch(max int) ([][]byte, status.Status)
+
 	// ReadWait returns a channel which is notified when more messages are available.
 	// The method returns a closed channel if the queue is closed.
 	ReadWait() <-chan struct{}
 
 	// Write
 
-	// Write writes an message to the queue, returns false if full, or an end if closed.
+	// Write writes an message to the queue, returns false if full or reserved, or an end if closed.
+	// The method returns an unavailable status if the alloc memory budget is exceeded.
 	Write(msg []byte) (bool, status.Status)
 
 	// WriteWait returns a channel which is notified when a message can be written.
 	// The method returns a closed channel if the queue is closed.
 	WriteWait(size int) <-chan struct{}
 
+	// Reserve reserves space for a message and returns a buffer to write the message to,
+	// returns false if full or reserved, or an end if closed.
+	//
+	// Only one reservation can be in flight, other writes return false until the reservation
+	// is committed or cancelled, WriteWait is notified then. Clear, Reset and Free cancel
+	// the reservation, the reserved buffer stays valid until Commit or Cancel.
+	//
+	// Usage:
+	//
+	//	buf, token, ok, st := q.Reserve(8)
+	//	if !ok || !st.OK() {
+	//		return
+	//	}
+	//	binary.BigEndian.PutUint64(buf, 1234)
+	//	q.Commit(token)
+	Reserve(size int) ([]byte, Token, bool, status.Status)
+
+	// Commit commits a reserved message and makes it available to the reader.
+	// The message is discarded if the reservation has been cancelled by Clear, Reset or Free.
+	Commit(t Token)
+
+	// Cancel cancels a reservation, the reserved message is discarded.
+	Cancel(t Token)
+
 	// Reset
 
 	// Reset resets the queue, releases all unread messages, the queue can be used again.
@@ -61,6 +92,14 @@ type Queue interface {
 	Free()
 }
 
+// Token is a queue write reservation, see [Queue.Reserve].
+type Token struct {
+	id    uint64 // reservation id
+	block *block
+	start int32 // message start index
+	size  int32 // message size
+}
+
 // New allocates an unbounded byte queue.
 func New() Queue {
 	return newQueue(heap.Global, 0)
@@ -93,10 +132,15 @@ type queue struct {
 	rmu sync.Mutex
 
 	// state
-	mu     sync.Mutex
-	closed bool
-	head   *block // can be accessed atomically by reader
-	more   []*block
+	mu      sync.Mutex
+	closed  bool
+	reserve reservation   // in-flight write reservation
+	orphans []reservation // cancelled reservations, their blocks are freed on commit or cancel
+	head    *block        // can be accessed atomically by reader
+	more    []*block
+
+	// reader
+	batch [][]byte // last read batch, guarded by rmu
 }
 
 func newQueue(heap *heap.Heap, cap int) *queue {
@@ -117,7 +161,7 @@ func (q *queue) Closed() bool {
 	return q.closed
 }
 
-// Clear releases all unread messages.
+// Clear releases all unread messages, and cancels an in-flight reservation.
 func (q *queue) Clear() {
 	q.mu.Lock()
 	defer q.mu.Unlock()
@@ -159,6 +203,33 @@ func (q *queue) Read() ([]byte, bool, status.Status) {
 	return msg, true, status.OK
 }
 
+// ReadBatch reads up to max contiguous messages from the queue, the messages are valid
+// until the next call to read. The method returns an end status when there are no more items
+// and the queue is closed.
+func (q *queue) ReadBatch(max int) ([][]byte, status.Status) {
+	q.rmu.Lock()
+	defer q.rmu.Unlock()
+
+	clear(q.batch)
+	q.batch = q.batch[:0]
+
+	block, ok, st := q.readBlock()
+	switch {
+	case !st.OK():
+		return nil, st
+	case !ok:
+		return nil, status.OK
+	}
+
+	// Read messages up to the current write index
+	wi := block.loadWriteIndex()
+	for len(q.batch) < max && block.readIndex < wi {
+		msg := block.read()
+		q.batch = append(q.batch, msg)
+	}
+	return q.batch, status.OK
+}
+
 // ReadWait returns a channel which is notified when more messages are available.
 // The method returns a closed channel if the queue is closed.
 func (q *queue) ReadWait() <-chan struct{} {
@@ -187,7 +258,7 @@ func (q *queue) ReadWait() <-chan struct{} {
 	return q.readChan
 }
 
-// Write writes an message to the queue, returns false if full, or an end if closed.
+// Write writes an message to the queue, returns false if full or reserved, or an end if closed.
 func (q *queue) Write(msg []byte) (bool, status.Status) {
 	if len(msg) > math.MaxInt32 {
 		panic("message too large")
@@ -197,15 +268,21 @@ func (q *queue) Write(msg []byte) (bool, status.Status) {
 	defer q.mu.Unlock()
 	defer q.notifyRead()
 
-	if q.closed {
+	switch {
+	case q.closed:
 		return false, status.End
+	case q.reserved():
+		return false, status.OK
 	}
 
 	size := len(msg)
 
 	// Get a block to write to.
-	block, ok := q.writeBlock(size)
-	if !ok {
+	block, ok, st := q.writeBlock(size)
+	switch {
+	case !st.OK():
+		return false, st
+	case !ok:
 		return false, status.OK
 	}
 
@@ -225,9 +302,13 @@ func (q *queue) WriteWait(size int) <-chan struct{} {
 		return closedChan
 	}
 
-	_, ok := q.writeBlock(size)
-	if ok {
-		return closedChan
+	// Return closed chan on error, the next write returns the error.
+	// Await commit or cancel when reserved.
+	if !q.reserved() {
+		_, ok, st := q.writeBlock(size)
+		if ok || !st.OK() {
+			return closedChan
+		}
 	}
 
 	select {
@@ -238,7 +319,78 @@ func (q *queue) WriteWait(size int) <-chan struct{} {
 	return q.writeChan
 }
 
+// Reserve reserves space for a message and returns a buffer to write the message to,
+// returns false if full or reserved, or an end if closed.
+//
+// Only one reservation can be in flight, other writes return false until the reservation
+// is committed or cancelled, WriteWait is notified then. Clear, Reset and Free cancel
+// the reservation, the reserved buffer stays valid until Commit or Cancel.
+func (q *queue) Reserve(size int) ([]byte, Token, bool, status.Status) {
+	if size > math.MaxInt32-4 {
+		panic("message too large")
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	switch {
+	case q.closed:
+		return nil, Token{}, false, status.End
+	case q.reserved():
+		return nil, Token{}, false, status.OK
+	}
+
+	// Get a block to write to.
+	block, ok, st := q.writeBlock(size)
+	switch {
+	case !st.OK():
+		return nil, Token{}, false, st
+	case !ok:
+		return nil, Token{}, false, status.OK
+	}
+
+	// Reserve message.
+	q.reserve.id++
+	q.reserve.block = block
+	start := block.writeIndex
+	buf := block.reserve(start, size)
+
+	t := Token{
+		id:    q.reserve.id,
+		block: block,
+		start: start,
+		size:  int32(size),
+	}
+	return buf, t, true, status.OK
+}
+
+// Commit commits a reserved message and makes it available to the reader.
+// The message is discarded if the reservation has been cancelled by Clear, Reset or Free.
+func (q *queue) Commit(t Token) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	defer q.notifyWrite()
+
+	if q.endReservation(t) {
+		return
+	}
+
+	wi := t.block.commit(t.start, t.size)
+	t.block.storeWriteIndex(wi)
+	q.notifyRead()
+}
+
+// Cancel cancels a reservation, the reserved message is discarded.
+func (q *queue) Cancel(t Token) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	defer q.notifyWrite()
+
+	q.endReservation(t)
+}
+
 // Reset resets the queue, releases all unread messages, the queue can be used again.
+// The method cancels an in-flight reservation.
 func (q *queue) Reset() {
 	q.mu.Lock()
 	defer q.mu.Unlock()
@@ -250,6 +402,7 @@ func (q *queue) Reset() {
 }
 
 // Free releases the queue and its internal resources.
+// The method cancels an in-flight reservation.
 func (q *queue) Free() {
 	q.mu.Lock()
 	defer q.mu.Unlock()
@@ -291,8 +444,11 @@ func (q *queue) readBlock() (*block, bool, status.Status) {
 		}
 
 		// Head is empty, it can be reset or released.
+		// Do not reset head when a writer has reserved space in it.
 		if len(q.more) == 0 {
-			head.reset()
+			if !q.reserved() {
+				head.reset()
+			}
 			break
 		}
 
@@ -341,13 +497,13 @@ func (q *queue) notifyReadAll() {
 // write
 
 // writeBlock returns or allocates a block to write to.
-func (q *queue) writeBlock(size int) (*block, bool) {
+func (q *queue) writeBlock(size int) (*block, bool, status.Status) {
 	n := 4 + size
 
 	// Return tail if it has enough free space.
 	tail := q.tail()
 	if tail != nil && tail.rem() >= n {
-		return tail, true
+		return tail, true, status.OK
 	}
 
 	// Check queue is not full. Messages can be larger than the queue max capacity.
@@ -356,19 +512,22 @@ func (q *queue) writeBlock(size int) (*block, bool) {
 		large := n > q.cap
 		if large {
 			if len(q.more) > 0 {
-				return nil, false
+				return nil, false, status.OK
 			}
 		} else {
 			total := q.occupied()
 			if total >= q.cap {
-				return nil, false
+				return nil, false, status.OK
 			}
 		}
 	}
 
 	// Allocate a new block.
-	block := q.alloc(n)
-	return block, true
+	block, st := q.alloc(n)
+	if !st.OK() {
+		return nil, false, st
+	}
+	return block, true, status.OK
 }
 
 // notifyWrite notifies a waiting writer.
@@ -396,6 +555,41 @@ func (q *queue) notifyWriteAll() {
 	}
 }
 
+// reserve
+
+// reservation is a write reservation.
+type reservation struct {
+	id    uint64 // incremented on each reservation
+	block *block // reserved block, nil when no reservation
+}
+
+// reserved returns true if a reservation is in flight.
+func (q *queue) reserved() bool {
+	return q.reserve.block != nil
+}
+
+// endReservation ends a reservation, returns true if the reservation has been cancelled
+// by clear, reset or free, in this case the method frees the reserved block.
+func (q *queue) endReservation(t Token) bool {
+	if q.reserve.block != nil && q.reserve.id == t.id {
+		q.reserve.block = nil
+		return false
+	}
+
+	for i, r := range q.orphans {
+		if r.id != t.id {
+			continue
+		}
+
+		q.orphans = slices.Delete(q.orphans, i, i+1)
+		r.block.free(q.heap)
+		releaseBlock(r.block)
+		return true
+	}
+
+	panic("no reservation")
+}
+
 // private
 
 // occupied returns the total written bytes.
@@ -422,8 +616,8 @@ func (q *queue) occupied() int {
 	return n
 }
 
-// alloc allocates a new block.
-func (q *queue) alloc(n int) *block {
+// alloc allocates a new block, or returns an error if the memory budget is exceeded.
+func (q *queue) alloc(n int) (*block, status.Status) {
 	size := 0
 
 	// Double tail block capacity if possible,
@@ -449,7 +643,10 @@ func (q *queue) alloc(n int) *block {
 	}
 
 	// Allocate new block.
-	b := q.heap.Alloc(size)
+	b, st := q.heap.TryAlloc(size)
+	if !st.OK() {
+		return nil, st
+	}
 	block := newBlock(b)
 
 	if q.head == nil {
@@ -457,27 +654,37 @@ func (q *queue) alloc(n int) *block {
 	} else {
 		q.more = append(q.more, block)
 	}
-	return block
+	return block, status.OK
 }
 
+// freeBlocks frees the blocks, cancels an in-flight reservation, the reserved block is freed
+// on commit or cancel.
 func (q *queue) freeBlocks() {
 	if q.head != nil {
 		b := q.head
 		q.head = nil
-
-		b.free(q.heap)
-		releaseBlock(b)
+		q.freeBlock(b)
 	}
 
 	for _, b := range q.more {
-		b.free(q.heap)
-		releaseBlock(b)
+		q.freeBlock(b)
 	}
 
 	slices2.Truncate(q.more)
 	q.more = q.more[:0]
 }
 
+func (q *queue) freeBlock(b *block) {
+	if b == q.reserve.block {
+		q.orphans = append(q.orphans, q.reserve)
+		q.reserve.block = nil
+		return
+	}
+
+	b.free(q.heap)
+	releaseBlock(b)
+}
+
 // head
 
 func (q *queue) loadHead() *block {
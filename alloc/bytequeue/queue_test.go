@@ -436,3 +436,199 @@ func TestQueue_Free__should_free_blocks(t *testing.T) {
 	assert.Nil(t, q.head)
 	assert.Equal(t, 0, len(q.more))
 }
+
+func TestQueue_Write__should_return_error_when_budget_exceeded(t *testing.T) {
+	h := heap.New()
+	h.SetBudget(heap.Budget{HardLimit: 1024})
+
+	q := newQueue(h, 0)
+	defer q.Free()
+
+	msg := make([]byte, 2048)
+	ok, st := q.Write(msg)
+	assert.False(t, ok)
+	assert.Equal(t, status.CodeUnavailable, st.Code)
+}
+
+// Reserve
+
+func TestQueue_Reserve__should_reserve_and_commit_message(t *testing.T) {
+	h := heap.New()
+	q := newQueue(h, 0)
+
+	buf, token, ok, st := q.Reserve(5)
+	require.True(t, st.OK())
+	require.True(t, ok)
+	require.Len(t, buf, 5)
+	copy(buf, "hello")
+
+	// Reader is not locked
+	_, ok, _ = q.Read()
+	assert.False(t, ok)
+
+	q.Commit(token)
+	msg := testRead(t, q)
+	assert.Equal(t, "hello", string(msg))
+}
+
+func TestQueue_Reserve__should_discard_message_on_cancel(t *testing.T) {
+	h := heap.New()
+	q := newQueue(h, 0)
+
+	buf, token, ok, _ := q.Reserve(5)
+	require.True(t, ok)
+	copy(buf, "hello")
+	q.Cancel(token)
+
+	testWrite(t, q, []byte("world"))
+	msg := testRead(t, q)
+	assert.Equal(t, "world", string(msg))
+}
+
+func TestQueue_Reserve__should_return_false_when_full(t *testing.T) {
+	h := heap.New()
+	q := newQueue(h, 1024)
+
+	msg := bytes.Repeat([]byte("a"), 1024-4)
+	testWrite(t, q, msg)
+
+	_, _, ok, st := q.Reserve(16)
+	assert.True(t, st.OK())
+	assert.False(t, ok)
+
+	// Writers are not locked
+	ok, st = q.Write(nil)
+	assert.True(t, st.OK())
+	assert.False(t, ok)
+}
+
+func TestQueue_Reserve__should_return_end_when_closed(t *testing.T) {
+	h := heap.New()
+	q := newQueue(h, 0)
+	q.Close()
+
+	_, _, ok, st := q.Reserve(16)
+	assert.False(t, ok)
+	assert.Equal(t, status.End, st)
+}
+
+func TestQueue_Reserve__should_return_false_to_writers_until_commit(t *testing.T) {
+	h := heap.New()
+	q := newQueue(h, 0)
+	defer q.Free()
+
+	buf, token, ok, _ := q.Reserve(5)
+	require.True(t, ok)
+	copy(buf, "hello")
+
+	ok, st := q.Write([]byte("world"))
+	require.True(t, st.OK())
+	assert.False(t, ok)
+
+	_, _, ok, _ = q.Reserve(5)
+	assert.False(t, ok)
+
+	wait := q.WriteWait(5)
+	select {
+	case <-wait:
+		t.Fatal("write wait must block")
+	default:
+	}
+
+	q.Commit(token)
+	select {
+	case <-wait:
+	default:
+		t.Fatal("write wait must be notified")
+	}
+
+	testWrite(t, q, []byte("world"))
+	assert.Equal(t, "hello", string(testRead(t, q)))
+	assert.Equal(t, "world", string(testRead(t, q)))
+}
+
+func TestQueue_Reserve__should_be_cancelled_by_reset(t *testing.T) {
+	h := heap.New()
+	q := newQueue(h, 0)
+	defer q.Free()
+
+	buf, token, ok, _ := q.Reserve(5)
+	require.True(t, ok)
+
+	q.Reset()
+	copy(buf, "hello")
+
+	// Writers are not locked
+	testWrite(t, q, []byte("world"))
+
+	q.Commit(token)
+	assert.Empty(t, q.orphans)
+	assert.Equal(t, "world", string(testRead(t, q)))
+
+	_, ok, _ = q.Read()
+	assert.False(t, ok)
+}
+
+func TestQueue_Reserve__should_not_block_free_and_clear(t *testing.T) {
+	h := heap.New()
+	q := newQueue(h, 0)
+
+	_, token, ok, _ := q.Reserve(5)
+	require.True(t, ok)
+
+	q.Clear()
+	q.Free()
+
+	q.Cancel(token)
+	assert.Empty(t, q.orphans)
+	assert.Equal(t, int64(0), h.Stats().InUse)
+}
+
+func TestQueue_Commit__should_panic_without_reservation(t *testing.T) {
+	h := heap.New()
+	q := newQueue(h, 0)
+	defer q.Free()
+
+	_, token, ok, _ := q.Reserve(5)
+	require.True(t, ok)
+	q.Cancel(token)
+
+	assert.Panics(t, func() {
+		q.Commit(token)
+	})
+}
+
+// ReadBatch
+
+func TestQueue_ReadBatch__should_read_multiple_messages(t *testing.T) {
+	h := heap.New()
+	q := newQueue(h, 0)
+
+	testWrite(t, q, []byte("a"))
+	testWrite(t, q, []byte("b"))
+	testWrite(t, q, []byte("c"))
+
+	batch, st := q.ReadBatch(2)
+	require.True(t, st.OK())
+	require.Len(t, batch, 2)
+	assert.Equal(t, "a", string(batch[0]))
+	assert.Equal(t, "b", string(batch[1]))
+
+	batch, st = q.ReadBatch(2)
+	require.True(t, st.OK())
+	require.Len(t, batch, 1)
+	assert.Equal(t, "c", string(batch[0]))
+
+	batch, st = q.ReadBatch(2)
+	require.True(t, st.OK())
+	assert.Len(t, batch, 0)
+}
+
+func TestQueue_ReadBatch__should_return_end_when_closed(t *testing.T) {
+	h := heap.New()
+	q := newQueue(h, 0)
+	q.Close()
+
+	_, st := q.ReadBatch(16)
+	assert.Equal(t, status.End, st)
+}
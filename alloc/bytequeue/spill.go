@@ -0,0 +1,592 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package bytequeue
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sync"
+
+	"github.com/basecomplextech/baselibrary/alloc/internal/heap"
+	"github.com/basecomplextech/baselibrary/filesys"
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// SpillOptions specifies the spill queue options.
+type SpillOptions struct {
+	// FS is the file system for segment files.
+	FS filesys.FileSystem
+
+	// Dir is an existing directory for segment files.
+	Dir string
+
+	// Threshold is the in-memory occupancy in bytes after which messages are spilled to disk,
+	// zero means the default threshold.
+	Threshold int
+
+	// SegmentSize is the max size of a segment file in bytes, zero means the default size.
+	// Messages larger than the segment size are written to separate segments.
+	SegmentSize int
+}
+
+// DefaultSpillOptions returns the default spill options.
+func DefaultSpillOptions(fs filesys.FileSystem, dir string) SpillOptions {
+	return SpillOptions{
+		FS:          fs,
+		Dir:         dir,
+		Threshold:   spillThreshold,
+		SegmentSize: spillSegmentSize,
+	}
+}
+
+// NewSpill allocates an unbounded byte queue which keeps up to the threshold bytes in memory,
+// and appends other messages to segment files. The messages are read back transparently in order.
+//
+// The queue never blocks writers unless a reservation is in flight, in this case writes return
+// false and WriteWait is notified when the reservation is committed or cancelled.
+// Write returns an error status if the segment files cannot be written. A commit failure
+// is stored in the queue, and is returned by the next reads and writes until Reset.
+func NewSpill(opts SpillOptions) Queue {
+	return newSpillQueue(heap.Global, opts)
+}
+
+// internal
+
+var _ Queue = (*spillQueue)(nil)
+
+const (
+	spillThreshold   = 16 << 20 // 16MB
+	spillSegmentSize = 64 << 20 // 64MB
+	spillBufferSize  = 64 << 10 // 64K
+)
+
+type spillQueue struct {
+	opts SpillOptions
+	mem  *queue
+
+	// channels for reader/writer to wait on
+	readChan  chan struct{}
+	writeChan chan struct{}
+
+	// force single reader
+	rmu sync.Mutex
+
+	// state
+	mu       sync.Mutex
+	closed   bool
+	failed   status.Status   // commit write error returned until reset, OK when none
+	seq      int             // next segment number
+	segments []*spillSegment // first is read, last is written
+	wbuf     []byte          // write buffer of the last segment
+	token    Token           // in-flight reservation, zero when none
+	tokenID  uint64          // last disk reservation id
+	reserve  []byte          // reserved message buffer when spilling
+
+	// reader
+	rbuf  []byte   // read buffer, guarded by rmu
+	batch [][]byte // last read batch, guarded by rmu
+	sizes []int    // batch message sizes, guarded by rmu
+}
+
+type spillSegment struct {
+	file    filesys.File
+	size    int64 // written bytes, including buffered ones
+	flushed int64 // flushed bytes
+	offset  int64 // read offset
+}
+
+func newSpillQueue(h *heap.Heap, opts SpillOptions) *spillQueue {
+	if opts.Threshold <= 0 {
+		opts.Threshold = spillThreshold
+	}
+	if opts.SegmentSize <= 0 {
+		opts.SegmentSize = spillSegmentSize
+	}
+
+	return &spillQueue{
+		opts:   opts,
+		mem:    newQueue(h, opts.Threshold),
+		failed: status.OK,
+
+		readChan:  make(chan struct{}, 1),
+		writeChan: make(chan struct{}, 1),
+	}
+}
+
+// Closed returns true if the queue is closed.
+func (q *spillQueue) Closed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.closed
+}
+
+// Clear releases all unread messages, and cancels an in-flight reservation.
+func (q *spillQueue) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.mem.Clear()
+	q.removeSegments()
+	q.cancelReservation()
+}
+
+// Close closes the queue for writing, it is still possible to read pending messages.
+func (q *spillQueue) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+
+	q.closed = true
+	q.mem.Close()
+	q.notifyRead()
+}
+
+// Read
+
+// Read reads an message from the queue, the message is valid until the next call to read.
+// The method returns an end status when there are no more items and the queue is closed.
+func (q *spillQueue) Read() ([]byte, bool, status.Status) {
+	q.rmu.Lock()
+	defer q.rmu.Unlock()
+
+	// Fast path: read from memory.
+	msg, ok, st := q.mem.Read()
+	if ok {
+		return msg, true, status.OK
+	}
+
+	// Slow path
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// Check memory again, writers could have written to it before spilling.
+	msg, ok, st = q.mem.Read()
+	switch {
+	case ok:
+		return msg, true, status.OK
+	case !q.failed.OK():
+		return nil, false, q.failed
+	case len(q.segments) == 0:
+		return nil, false, st
+	case !st.OK() && st.Code != status.CodeEnd:
+		return nil, false, st
+	}
+
+	// Read from disk
+	q.rbuf = q.rbuf[:0]
+	return q.readDisk()
+}
+
+// ReadBatch reads up to max contiguous messages from the queue, the messages are valid
+// until the next call to read. The method returns an end status when there are no more items
+// and the queue is closed.
+func (q *spillQueue) ReadBatch(max int) ([][]byte, status.Status) {
+	q.rmu.Lock()
+	defer q.rmu.Unlock()
+
+	// Fast path: read from memory.
+	batch, st := q.mem.ReadBatch(max)
+	if len(batch) > 0 {
+		return batch, status.OK
+	}
+
+	// Slow path
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// Check memory again, writers could have written to it before spilling.
+	batch, st = q.mem.ReadBatch(max)
+	switch {
+	case len(batch) > 0:
+		return batch, status.OK
+	case !q.failed.OK():
+		return nil, q.failed
+	case len(q.segments) == 0:
+		return nil, st
+	case !st.OK() && st.Code != status.CodeEnd:
+		return nil, st
+	}
+
+	// Read messages from disk into one buffer, and slice them afterwards,
+	// because the buffer can be reallocated.
+	clear(q.batch)
+	q.batch = q.batch[:0]
+	q.rbuf = q.rbuf[:0]
+	q.sizes = q.sizes[:0]
+
+	for len(q.sizes) < max && len(q.segments) > 0 {
+		msg, _, st := q.readDisk()
+		if !st.OK() {
+			return nil, st
+		}
+		q.sizes = append(q.sizes, len(msg))
+	}
+
+	off := 0
+	for _, size := range q.sizes {
+		msg := q.rbuf[off : off+size : off+size]
+		q.batch = append(q.batch, msg)
+		off += size
+	}
+	return q.batch, status.OK
+}
+
+// ReadWait returns a channel which is notified when more messages are available.
+// The method returns a closed channel if the queue is closed.
+func (q *spillQueue) ReadWait() <-chan struct{} {
+	q.mu.Lock()
+	if q.closed || !q.failed.OK() || len(q.segments) > 0 {
+		q.mu.Unlock()
+		return closedChan
+	}
+
+	select {
+	case <-q.readChan:
+	default:
+	}
+	q.mu.Unlock()
+
+	if wait := q.mem.ReadWait(); wait == closedChan {
+		return closedChan
+	}
+	return q.readChan
+}
+
+// Write
+
+// Write writes an message to the queue, or returns an end if closed.
+func (q *spillQueue) Write(msg []byte) (bool, status.Status) {
+	if len(msg) > math.MaxInt32 {
+		panic("message too large")
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	defer q.notifyRead()
+
+	switch {
+	case q.closed:
+		return false, status.End
+	case !q.failed.OK():
+		return false, q.failed
+	case q.token != Token{}:
+		return false, status.OK
+	}
+
+	// Write to memory when not spilling
+	if len(q.segments) == 0 {
+		ok, st := q.mem.Write(msg)
+		switch {
+		case !st.OK():
+			return false, st
+		case ok:
+			return true, status.OK
+		}
+	}
+
+	// Append to disk
+	if st := q.writeDisk(msg); !st.OK() {
+		return false, st
+	}
+	return true, status.OK
+}
+
+// WriteWait returns a channel which is notified when a reservation is committed or cancelled.
+// The method returns a closed channel if there is no reservation, or the queue is closed.
+func (q *spillQueue) WriteWait(size int) <-chan struct{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || !q.failed.OK() || q.token == (Token{}) {
+		return closedChan
+	}
+
+	select {
+	case <-q.writeChan:
+	default:
+	}
+	return q.writeChan
+}
+
+// Reserve reserves space for a message and returns a buffer to write the message to,
+// returns false if reserved, or an end if closed.
+//
+// Only one reservation can be in flight, other writes return false until the reservation
+// is committed or cancelled, WriteWait is notified then. Clear, Reset and Free cancel
+// the reservation, the reserved buffer stays valid until Commit or Cancel.
+func (q *spillQueue) Reserve(size int) ([]byte, Token, bool, status.Status) {
+	if size > math.MaxInt32-4 {
+		panic("message too large")
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	switch {
+	case q.closed:
+		return nil, Token{}, false, status.End
+	case !q.failed.OK():
+		return nil, Token{}, false, q.failed
+	case q.token != Token{}:
+		return nil, Token{}, false, status.OK
+	}
+
+	// Reserve in memory when not spilling
+	if len(q.segments) == 0 {
+		buf, t, ok, st := q.mem.Reserve(size)
+		switch {
+		case !st.OK():
+			return nil, Token{}, false, st
+		case ok:
+			q.token = t
+			return buf, t, true, status.OK
+		}
+	}
+
+	// Reserve in a temporary buffer
+	if cap(q.reserve) < size {
+		q.reserve = make([]byte, size)
+	}
+	q.reserve = q.reserve[:size]
+
+	q.tokenID++
+	q.token = Token{id: q.tokenID, size: int32(size)}
+	return q.reserve, q.token, true, status.OK
+}
+
+// Commit commits a reserved message and makes it available to the reader.
+// The message is discarded if the reservation has been cancelled by Clear, Reset or Free.
+//
+// If the message cannot be written to disk, the error is stored in the queue,
+// and is returned by the next reads and writes until Reset.
+func (q *spillQueue) Commit(t Token) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	defer q.notifyWrite()
+	defer q.notifyRead()
+
+	current := q.endReservation(t)
+	if t.block != nil {
+		q.mem.Commit(t)
+		return
+	}
+	if !current {
+		return
+	}
+
+	if st := q.writeDisk(q.reserve); !st.OK() {
+		q.failed = st.WrapTextf("failed to commit message")
+	}
+}
+
+// Cancel cancels a reservation, the reserved message is discarded.
+func (q *spillQueue) Cancel(t Token) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	defer q.notifyWrite()
+
+	q.endReservation(t)
+	if t.block != nil {
+		q.mem.Cancel(t)
+	}
+}
+
+// Reset resets the queue, releases all unread messages, the queue can be used again.
+// The method cancels an in-flight reservation, and clears a commit error.
+func (q *spillQueue) Reset() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.mem.Reset()
+	q.removeSegments()
+	q.cancelReservation()
+	q.closed = false
+	q.failed = status.OK
+}
+
+// Free releases the queue, its internal resources and removes segment files.
+// The method cancels an in-flight reservation.
+func (q *spillQueue) Free() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.mem.Free()
+	q.removeSegments()
+	q.cancelReservation()
+}
+
+// private
+
+// readDisk appends the next message from the first segment to the read buffer.
+func (q *spillQueue) readDisk() ([]byte, bool, status.Status) {
+	s := q.segments[0]
+	last := len(q.segments) == 1
+
+	// Flush write buffer if required
+	if last && s.offset >= s.flushed {
+		if st := q.flush(); !st.OK() {
+			return nil, false, st
+		}
+	}
+
+	// Read message size
+	var hdr [4]byte
+	if _, err := s.file.ReadAt(hdr[:], s.offset); err != nil {
+		return nil, false, status.WrapError(err)
+	}
+	size := int(binary.BigEndian.Uint32(hdr[:]))
+
+	// Read message
+	start := len(q.rbuf)
+	q.rbuf = append(q.rbuf, make([]byte, size)...)
+	msg := q.rbuf[start : start+size : start+size]
+
+	if size > 0 {
+		if _, err := s.file.ReadAt(msg, s.offset+4); err != nil {
+			return nil, false, status.WrapError(err)
+		}
+	}
+	s.offset += 4 + int64(size)
+
+	// Remove segment when read
+	if s.offset == s.size {
+		if err := q.removeSegment(s); err != nil {
+			return nil, false, status.WrapError(err)
+		}
+	}
+	return msg, true, status.OK
+}
+
+// writeDisk appends a message to the last segment.
+func (q *spillQueue) writeDisk(msg []byte) status.Status {
+	n := int64(4 + len(msg))
+
+	// Create segment if required
+	var s *spillSegment
+	if len(q.segments) > 0 {
+		s = q.segments[len(q.segments)-1]
+	}
+	if s == nil || (s.size > 0 && s.size+n > int64(q.opts.SegmentSize)) {
+		if st := q.flush(); !st.OK() {
+			return st
+		}
+
+		var st status.Status
+		s, st = q.createSegment()
+		if !st.OK() {
+			return st
+		}
+	}
+
+	// Append message to buffer
+	q.wbuf = binary.BigEndian.AppendUint32(q.wbuf, uint32(len(msg)))
+	q.wbuf = append(q.wbuf, msg...)
+	s.size += n
+
+	if len(q.wbuf) >= spillBufferSize {
+		return q.flush()
+	}
+	return status.OK
+}
+
+// flush writes the buffer to the last segment.
+func (q *spillQueue) flush() status.Status {
+	if len(q.wbuf) == 0 {
+		return status.OK
+	}
+
+	s := q.segments[len(q.segments)-1]
+	if _, err := s.file.Write(q.wbuf); err != nil {
+		return status.WrapError(err)
+	}
+
+	s.flushed += int64(len(q.wbuf))
+	q.wbuf = q.wbuf[:0]
+	return status.OK
+}
+
+// segments
+
+func (q *spillQueue) createSegment() (*spillSegment, status.Status) {
+	name := fmt.Sprintf("segment-%08d", q.seq)
+	path := filepath.Join(q.opts.Dir, name)
+	q.seq++
+
+	file, err := q.opts.FS.Create(path)
+	if err != nil {
+		return nil, status.WrapError(err)
+	}
+
+	s := &spillSegment{file: file}
+	q.segments = append(q.segments, s)
+	return s, status.OK
+}
+
+func (q *spillQueue) removeSegment(s *spillSegment) error {
+	q.segments[0] = nil
+	q.segments = q.segments[1:]
+
+	path := s.file.Path()
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	return q.opts.FS.Remove(path)
+}
+
+func (q *spillQueue) removeSegments() {
+	for len(q.segments) > 0 {
+		s := q.segments[0]
+		q.removeSegment(s) // ignore errors
+	}
+	q.wbuf = q.wbuf[:0]
+}
+
+// reserve
+
+// endReservation ends a reservation, returns false if the reservation has been cancelled.
+func (q *spillQueue) endReservation(t Token) bool {
+	if t != q.token {
+		return false
+	}
+
+	q.token = Token{}
+	return true
+}
+
+// cancelReservation cancels an in-flight reservation, the memory reservation is cancelled
+// by the memory queue, the disk reservation buffer is left to the writer.
+func (q *spillQueue) cancelReservation() {
+	if q.token == (Token{}) {
+		return
+	}
+
+	if q.token.block == nil {
+		q.reserve = nil
+	}
+	q.token = Token{}
+	q.notifyWrite()
+}
+
+// notify
+
+func (q *spillQueue) notifyRead() {
+	select {
+	case q.readChan <- struct{}{}:
+	default:
+	}
+}
+
+func (q *spillQueue) notifyWrite() {
+	select {
+	case q.writeChan <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,373 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package bytequeue
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/alloc/internal/heap"
+	"github.com/basecomplextech/baselibrary/filesys"
+	"github.com/basecomplextech/baselibrary/filesys/testfs"
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSpillQueue(t *testing.T, threshold int, segmentSize int) (*spillQueue, filesys.FileSystem) {
+	fs, dir := testfs.Test(t)
+	q := newSpillQueue(heap.New(), SpillOptions{
+		FS:          fs,
+		Dir:         dir,
+		Threshold:   threshold,
+		SegmentSize: segmentSize,
+	})
+	t.Cleanup(q.Free)
+	return q, fs
+}
+
+func testSpillWrite(t *testing.T, q *spillQueue, msg []byte) {
+	t.Helper()
+
+	ok, st := q.Write(msg)
+	if !st.OK() {
+		t.Fatal(st)
+	}
+	if !ok {
+		t.Fatal("write failed")
+	}
+}
+
+func testSpillRead(t *testing.T, q *spillQueue) []byte {
+	t.Helper()
+
+	msg, ok, st := q.Read()
+	if !st.OK() {
+		t.Fatal(st)
+	}
+	if !ok {
+		t.Fatal("read failed")
+	}
+	return msg
+}
+
+// testFailFS is a file system which fails to create files.
+type testFailFS struct {
+	filesys.FileSystem
+}
+
+func (fs testFailFS) Create(name string) (filesys.File, error) {
+	return nil, errors.New("create failed")
+}
+
+func testSpillMessage(i int) []byte {
+	return []byte(fmt.Sprintf("message-%04d", i))
+}
+
+// Write
+
+func TestSpillQueue_Write__should_spill_messages_to_disk_when_threshold_exceeded(t *testing.T) {
+	q, _ := testSpillQueue(t, 1024, 0)
+
+	for i := 0; i < 1000; i++ {
+		testSpillWrite(t, q, testSpillMessage(i))
+	}
+
+	assert.Len(t, q.segments, 1)
+	assert.LessOrEqual(t, q.mem.occupied(), 2048)
+}
+
+func TestSpillQueue_Write__should_rollover_segments(t *testing.T) {
+	q, _ := testSpillQueue(t, 1024, 256)
+
+	for i := 0; i < 1000; i++ {
+		testSpillWrite(t, q, testSpillMessage(i))
+	}
+
+	assert.Greater(t, len(q.segments), 1)
+}
+
+func TestSpillQueue_Write__should_return_end_when_closed(t *testing.T) {
+	q, _ := testSpillQueue(t, 1024, 0)
+	q.Close()
+
+	ok, st := q.Write([]byte("hello"))
+	assert.False(t, ok)
+	assert.Equal(t, status.End, st)
+}
+
+// Read
+
+func TestSpillQueue_Read__should_read_messages_in_order(t *testing.T) {
+	q, _ := testSpillQueue(t, 1024, 256)
+
+	for i := 0; i < 1000; i++ {
+		testSpillWrite(t, q, testSpillMessage(i))
+	}
+
+	for i := 0; i < 1000; i++ {
+		msg := testSpillRead(t, q)
+		require.Equal(t, testSpillMessage(i), msg)
+	}
+
+	_, ok, st := q.Read()
+	assert.False(t, ok)
+	assert.True(t, st.OK())
+}
+
+func TestSpillQueue_Read__should_read_interleaved_writes_in_order(t *testing.T) {
+	q, _ := testSpillQueue(t, 1024, 256)
+
+	w, r := 0, 0
+	for round := 0; round < 10; round++ {
+		for i := 0; i < 200; i++ {
+			testSpillWrite(t, q, testSpillMessage(w))
+			w++
+		}
+		for i := 0; i < 150; i++ {
+			msg := testSpillRead(t, q)
+			require.Equal(t, testSpillMessage(r), msg)
+			r++
+		}
+	}
+
+	for r < w {
+		msg := testSpillRead(t, q)
+		require.Equal(t, testSpillMessage(r), msg)
+		r++
+	}
+	assert.Len(t, q.segments, 0)
+}
+
+func TestSpillQueue_Read__should_remove_read_segments(t *testing.T) {
+	q, fs := testSpillQueue(t, 1024, 256)
+
+	for i := 0; i < 1000; i++ {
+		testSpillWrite(t, q, testSpillMessage(i))
+	}
+	path := q.segments[0].file.Path()
+
+	for i := 0; i < 1000; i++ {
+		testSpillRead(t, q)
+	}
+
+	ok, err := fs.Exists(path)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Len(t, q.segments, 0)
+}
+
+func TestSpillQueue_Read__should_return_end_when_closed_and_drained(t *testing.T) {
+	q, _ := testSpillQueue(t, 1024, 0)
+
+	for i := 0; i < 200; i++ {
+		testSpillWrite(t, q, testSpillMessage(i))
+	}
+	q.Close()
+
+	for i := 0; i < 200; i++ {
+		msg := testSpillRead(t, q)
+		require.Equal(t, testSpillMessage(i), msg)
+	}
+
+	_, ok, st := q.Read()
+	assert.False(t, ok)
+	assert.Equal(t, status.End, st)
+}
+
+// ReadBatch
+
+func TestSpillQueue_ReadBatch__should_read_messages_from_disk(t *testing.T) {
+	q, _ := testSpillQueue(t, 1024, 256)
+
+	for i := 0; i < 1000; i++ {
+		testSpillWrite(t, q, testSpillMessage(i))
+	}
+
+	n := 0
+	for n < 1000 {
+		batch, st := q.ReadBatch(16)
+		require.True(t, st.OK())
+		require.NotEmpty(t, batch)
+
+		for _, msg := range batch {
+			require.Equal(t, testSpillMessage(n), msg)
+			n++
+		}
+	}
+}
+
+// ReadWait
+
+func TestSpillQueue_ReadWait__should_return_closed_channel_when_spilled(t *testing.T) {
+	q, _ := testSpillQueue(t, 1024, 0)
+
+	for i := 0; i < 200; i++ {
+		testSpillWrite(t, q, testSpillMessage(i))
+	}
+	for i := 0; i < 100; i++ {
+		testSpillRead(t, q)
+	}
+
+	wait := q.ReadWait()
+	assert.Equal(t, (<-chan struct{})(closedChan), wait)
+}
+
+func TestSpillQueue_ReadWait__should_notify_on_write(t *testing.T) {
+	q, _ := testSpillQueue(t, 1024, 0)
+
+	wait := q.ReadWait()
+	select {
+	case <-wait:
+		t.Fatal("unexpected notification")
+	default:
+	}
+
+	testSpillWrite(t, q, []byte("hello"))
+
+	select {
+	case <-wait:
+	default:
+		t.Fatal("expected notification")
+	}
+}
+
+// Reserve
+
+func TestSpillQueue_Reserve__should_reserve_message_on_disk_when_spilling(t *testing.T) {
+	q, _ := testSpillQueue(t, 1024, 0)
+
+	for i := 0; i < 200; i++ {
+		testSpillWrite(t, q, testSpillMessage(i))
+	}
+	require.NotEmpty(t, q.segments)
+
+	buf, token, ok, st := q.Reserve(5)
+	require.True(t, st.OK())
+	require.True(t, ok)
+	copy(buf, "hello")
+	q.Commit(token)
+
+	for i := 0; i < 200; i++ {
+		testSpillRead(t, q)
+	}
+
+	msg := testSpillRead(t, q)
+	assert.Equal(t, []byte("hello"), msg)
+}
+
+func TestSpillQueue_Reserve__should_return_false_to_writers_until_commit(t *testing.T) {
+	q, _ := testSpillQueue(t, 1024, 0)
+
+	buf, token, ok, _ := q.Reserve(5)
+	require.True(t, ok)
+	copy(buf, "hello")
+
+	ok, st := q.Write([]byte("world"))
+	require.True(t, st.OK())
+	assert.False(t, ok)
+
+	wait := q.WriteWait(5)
+	select {
+	case <-wait:
+		t.Fatal("write wait must block")
+	default:
+	}
+
+	q.Commit(token)
+	select {
+	case <-wait:
+	default:
+		t.Fatal("write wait must be notified")
+	}
+
+	testSpillWrite(t, q, []byte("world"))
+	assert.Equal(t, []byte("hello"), testSpillRead(t, q))
+	assert.Equal(t, []byte("world"), testSpillRead(t, q))
+}
+
+func TestSpillQueue_Reserve__should_discard_disk_message_cancelled_by_reset(t *testing.T) {
+	q, _ := testSpillQueue(t, 1024, 0)
+
+	for i := 0; i < 200; i++ {
+		testSpillWrite(t, q, testSpillMessage(i))
+	}
+	require.NotEmpty(t, q.segments)
+
+	buf, token, ok, _ := q.Reserve(5)
+	require.True(t, ok)
+
+	q.Reset()
+	copy(buf, "hello")
+
+	testSpillWrite(t, q, []byte("world"))
+	q.Commit(token)
+
+	assert.Equal(t, []byte("world"), testSpillRead(t, q))
+	_, ok, _ = q.Read()
+	assert.False(t, ok)
+}
+
+func TestSpillQueue_Commit__should_return_write_error_from_next_calls(t *testing.T) {
+	fs, dir := testfs.Test(t)
+	q := newSpillQueue(heap.New(), SpillOptions{
+		FS:        testFailFS{fs},
+		Dir:       dir,
+		Threshold: 1024,
+	})
+	defer q.Free()
+	testSpillWrite(t, q, make([]byte, 1024))
+	testSpillWrite(t, q, make([]byte, 1024))
+
+	_, token, ok, st := q.Reserve(1024)
+	require.True(t, st.OK())
+	require.True(t, ok)
+	require.Nil(t, token.block)
+	q.Commit(token)
+
+	ok, st = q.Write([]byte("hello"))
+	assert.False(t, ok)
+	assert.Equal(t, status.CodeError, st.Code)
+
+	assert.Len(t, testSpillRead(t, q), 1024)
+	assert.Len(t, testSpillRead(t, q), 1024)
+
+	_, ok, st = q.Read()
+	assert.False(t, ok)
+	assert.Equal(t, status.CodeError, st.Code)
+	assert.Equal(t, (<-chan struct{})(closedChan), q.ReadWait())
+
+	q.Reset()
+	testSpillWrite(t, q, []byte("hello"))
+	assert.Equal(t, []byte("hello"), testSpillRead(t, q))
+}
+
+// Free
+
+func TestSpillQueue_Free__should_remove_segments(t *testing.T) {
+	fs, dir := testfs.Test(t)
+	q := newSpillQueue(heap.New(), SpillOptions{
+		FS:          fs,
+		Dir:         dir,
+		Threshold:   1024,
+		SegmentSize: 256,
+	})
+
+	for i := 0; i < 1000; i++ {
+		testSpillWrite(t, q, testSpillMessage(i))
+	}
+	paths := make([]string, 0, len(q.segments))
+	for _, s := range q.segments {
+		paths = append(paths, s.file.Path())
+	}
+	q.Free()
+
+	for _, path := range paths {
+		ok, err := fs.Exists(path)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+}
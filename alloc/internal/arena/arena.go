@@ -5,11 +5,13 @@
 package arena
 
 import (
+	"runtime/debug"
 	"unsafe"
 
 	"github.com/basecomplextech/baselibrary/alloc/internal/heap"
 	"github.com/basecomplextech/baselibrary/buffer"
 	"github.com/basecomplextech/baselibrary/pools"
+	"github.com/basecomplextech/baselibrary/status"
 )
 
 // Arena is an arena allocator, which internally allocates memory in blocks.
@@ -25,11 +27,21 @@ type Arena interface {
 	// Methods
 
 	// Alloc allocates a memory block and returns a pointer to it.
+	// The method panics if the memory budget is exceeded, see [Arena.TryAlloc].
 	Alloc(size int) unsafe.Pointer
 
 	// Bytes allocates a byte slice.
+	// The method panics if the memory budget is exceeded, see [Arena.TryBytes].
 	Bytes(size int) []byte
 
+	// TryAlloc allocates a memory block and returns a pointer to it,
+	// or returns an unavailable status if the memory budget is exceeded.
+	TryAlloc(size int) (unsafe.Pointer, status.Status)
+
+	// TryBytes allocates a byte slice,
+	// or returns an unavailable status if the memory budget is exceeded.
+	TryBytes(size int) ([]byte, status.Status)
+
 	// Buffer allocates a buffer in the arena, the buffer cannot be freed.
 	Buffer() buffer.Buffer
 
@@ -40,6 +52,17 @@ type Arena interface {
 	// Reset resets the arena.
 	Reset()
 
+	// Mark returns a checkpoint of the arena current state.
+	Mark() Mark
+
+	// ResetTo releases all memory allocated after the mark.
+	//
+	// Objects allocated after the mark must not be used or even referenced after ResetTo.
+	// Pinned objects are retained until the next Reset.
+	// The mark is invalid after Reset or ResetTo to an earlier mark, except an empty mark
+	// taken when nothing was allocated, which always resets the arena.
+	ResetTo(m Mark)
+
 	// Internal
 
 	// Free frees the arena and releases its memory.
@@ -47,6 +70,13 @@ type Arena interface {
 	Free()
 }
 
+// Mark is an arena checkpoint, see [Arena.Mark] and [Arena.ResetTo].
+type Mark struct {
+	gen    uint64 // arena generation
+	blocks int    // number of blocks
+	len    int    // last block length
+}
+
 // New returns an empty non-thread-safe arena.
 func New() Arena {
 	return newArena(heap.Global)
@@ -69,10 +99,11 @@ func AcquireArena() Arena {
 
 type arena struct {
 	*state
+	freed []byte // free stack in debug mode
 }
 
 func newArena(h *heap.Heap) *arena {
-	a := &arena{acquireState()}
+	a := &arena{state: acquireState()}
 	a.heap = h
 	return a
 }
@@ -88,15 +119,29 @@ func (a *arena) Len() int64 {
 }
 
 // Alloc allocates a memory block and returns a pointer to it.
+// The method panics if the memory budget is exceeded, see [Arena.TryAlloc].
 func (a *arena) Alloc(size int) unsafe.Pointer {
 	return a.alloc(size)
 }
 
 // Bytes allocates a byte slice.
+// The method panics if the memory budget is exceeded, see [Arena.TryBytes].
 func (a *arena) Bytes(size int) []byte {
 	return a.bytes(size)
 }
 
+// TryAlloc allocates a memory block and returns a pointer to it,
+// or returns an unavailable status if the memory budget is exceeded.
+func (a *arena) TryAlloc(size int) (unsafe.Pointer, status.Status) {
+	return a.tryAlloc(size)
+}
+
+// TryBytes allocates a byte slice,
+// or returns an unavailable status if the memory budget is exceeded.
+func (a *arena) TryBytes(size int) ([]byte, status.Status) {
+	return a.tryBytes(size)
+}
+
 // Buffer allocates a buffer in the arena, the buffer cannot be freed.
 func (a *arena) Buffer() buffer.Buffer {
 	b := Alloc[arenaBuffer](a)
@@ -115,11 +160,25 @@ func (a *arena) Reset() {
 	a.reset()
 }
 
+// Mark returns a checkpoint of the arena current state.
+func (a *arena) Mark() Mark {
+	return a.mark()
+}
+
+// ResetTo releases all memory allocated after the mark.
+func (a *arena) ResetTo(m Mark) {
+	a.resetTo(m)
+}
+
 // Internal
 
 // Free frees the arena and releases its memory.
 // The method is not thread-safe and must be called only once.
 func (a *arena) Free() {
+	if a.state == nil {
+		panicDoubleFree(a.freed)
+	}
+
 	if a.pooled {
 		releaseArena(a)
 		return
@@ -127,6 +186,9 @@ func (a *arena) Free() {
 
 	s := a.state
 	a.state = nil
+	if s.heap.IsDebug() {
+		a.freed = debug.Stack()
+	}
 	releaseState(s)
 }
 
@@ -141,6 +203,9 @@ var arenaPool = pools.NewPoolFunc(
 )
 
 func acquireArena() *arena {
+	if heap.Debug {
+		return newArena(heap.Global)
+	}
 	return arenaPool.New()
 }
 
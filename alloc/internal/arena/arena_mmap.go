@@ -0,0 +1,171 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package arena
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/basecomplextech/baselibrary/alloc/internal/heap"
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/edsrzf/mmap-go"
+)
+
+// MmapOptions specifies the mmap arena options.
+type MmapOptions struct {
+	// RegionSize is the min size of an mmap region, it is rounded up to the page size.
+	RegionSize int
+}
+
+// DefaultMmapOptions returns the default mmap arena options.
+func DefaultMmapOptions() MmapOptions {
+	return MmapOptions{
+		RegionSize: 1 << 20, // 1MB
+	}
+}
+
+// NewMmapArena returns a new non-thread-safe arena backed by anonymous mmap regions.
+//
+// The arena memory is invisible to the garbage collector, so the arena must not be used
+// to store pointers to the Go heap, use Pin to keep external objects alive.
+// The regions are reused on Reset and unmapped on Free.
+func NewMmapArena(opts MmapOptions) Arena {
+	return newMmapArena(opts)
+}
+
+// internal
+
+var _ Arena = (*mmapArena)(nil)
+
+type mmapArena struct {
+	*arena
+	heap *mmapHeap
+}
+
+func newMmapArena(opts MmapOptions) *mmapArena {
+	h := newMmapHeap(opts)
+
+	// Use own state, pooled states must point to the global heap
+	a := &arena{state: &state{heap: h}}
+
+	return &mmapArena{
+		arena: a,
+		heap:  h,
+	}
+}
+
+// Buffer allocates a buffer in the arena, the buffer cannot be freed.
+func (a *mmapArena) Buffer() buffer.Buffer {
+	b := Alloc[arenaBuffer](a)
+	b.init(a)
+	return b
+}
+
+// Internal
+
+// Free frees the arena and unmaps its memory.
+// The method is not thread-safe and must be called only once.
+func (a *mmapArena) Free() {
+	if a.state == nil {
+		panicDoubleFree(a.freed)
+	}
+
+	// Drop state, it is never returned to the pool
+	a.state = nil
+	a.heap.unmap()
+}
+
+// heap
+
+var _ blockHeap = (*mmapHeap)(nil)
+
+type mmapHeap struct {
+	size    int // region size
+	regions []mmap.MMap
+	free    []*heap.Block
+}
+
+func newMmapHeap(opts MmapOptions) *mmapHeap {
+	page := os.Getpagesize()
+
+	size := opts.RegionSize
+	if size < page {
+		size = page
+	}
+	size = roundUp(size, page)
+
+	return &mmapHeap{size: size}
+}
+
+// TryAlloc returns the smallest free block which fits the size, or maps a new region,
+// or returns an unavailable status if the region cannot be mapped.
+func (h *mmapHeap) TryAlloc(size int) (*heap.Block, status.Status) {
+	// Reuse best fit block
+	best := -1
+	for i, b := range h.free {
+		if b.Cap() < size {
+			continue
+		}
+		if best == -1 || b.Cap() < h.free[best].Cap() {
+			best = i
+		}
+	}
+
+	if best != -1 {
+		b := h.free[best]
+		last := len(h.free) - 1
+		h.free[best] = h.free[last]
+		h.free[last] = nil
+		h.free = h.free[:last]
+		return b, status.OK
+	}
+
+	// Map new region
+	n := h.size
+	if size > n {
+		n = roundUp(size, os.Getpagesize())
+	}
+
+	region, err := mmap.MapRegion(nil, n, mmap.RDWR, mmap.ANON, 0)
+	if err != nil {
+		return nil, status.Unavailablef("arena: failed to mmap %d bytes: %v", n, err)
+	}
+
+	h.regions = append(h.regions, region)
+	return heap.WrapBlock(region), status.OK
+}
+
+// FreeMany resets blocks and retains them for reuse.
+func (h *mmapHeap) FreeMany(blocks ...*heap.Block) {
+	for _, b := range blocks {
+		b.Reset()
+		h.free = append(h.free, b)
+	}
+}
+
+// IsDebug returns false, the mmap heap does not support the debug mode.
+func (h *mmapHeap) IsDebug() bool {
+	return false
+}
+
+// private
+
+func (h *mmapHeap) unmap() {
+	clear(h.free)
+	h.free = h.free[:0]
+
+	for i, region := range h.regions {
+		if err := region.Unmap(); err != nil {
+			panic(fmt.Sprintf("arena: failed to unmap region: %v", err))
+		}
+		h.regions[i] = nil
+	}
+	h.regions = h.regions[:0]
+}
+
+func roundUp(n int, align int) int {
+	return (n + align - 1) / align * align
+}
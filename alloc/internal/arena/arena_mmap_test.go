@@ -0,0 +1,132 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package arena
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMmapArena() *mmapArena {
+	opts := DefaultMmapOptions()
+	opts.RegionSize = 1
+	return newMmapArena(opts)
+}
+
+// Alloc
+
+func TestMmapArena_Alloc__should_allocate_in_mmap_region(t *testing.T) {
+	a := testMmapArena()
+	defer a.Free()
+
+	b := a.Bytes(16)
+	copy(b, "hello, world")
+
+	require.Len(t, a.heap.regions, 1)
+	assert.Equal(t, int64(os.Getpagesize()), a.Cap())
+	assert.Equal(t, int64(16), a.Len())
+	assert.Equal(t, "hello, world", string(b[:12]))
+}
+
+func TestMmapArena_Alloc__should_map_large_regions(t *testing.T) {
+	a := testMmapArena()
+	defer a.Free()
+
+	page := os.Getpagesize()
+	a.Bytes(page + 1)
+
+	require.Len(t, a.heap.regions, 1)
+	assert.Equal(t, int64(2*page), a.Cap())
+}
+
+// Buffer
+
+func TestMmapArena_Buffer__should_allocate_buffer_in_arena(t *testing.T) {
+	a := testMmapArena()
+	defer a.Free()
+
+	buf := a.Buffer()
+	buf.Write([]byte("hello, world"))
+
+	assert.Equal(t, "hello, world", string(buf.Bytes()))
+}
+
+// Reset
+
+func TestMmapArena_Reset__should_reuse_regions(t *testing.T) {
+	a := testMmapArena()
+	defer a.Free()
+
+	page := os.Getpagesize()
+	a.Bytes(page)
+	a.Bytes(page)
+	require.Len(t, a.heap.regions, 2)
+
+	a.Reset()
+	assert.Len(t, a.heap.free, 2)
+
+	b := a.Bytes(page)
+	assert.Equal(t, make([]byte, page), b)
+	assert.Len(t, a.heap.regions, 2)
+}
+
+func TestMmapArena_Alloc__should_reuse_best_fit_block(t *testing.T) {
+	a := testMmapArena()
+	defer a.Free()
+
+	page := os.Getpagesize()
+	large, _ := a.heap.TryAlloc(4 * page)
+	small, _ := a.heap.TryAlloc(page)
+	a.heap.FreeMany(large, small)
+
+	b, st := a.heap.TryAlloc(page)
+	require.True(t, st.OK())
+	assert.Same(t, small, b)
+}
+
+// ResetTo
+
+func TestMmapArena_ResetTo__should_release_blocks_after_mark(t *testing.T) {
+	a := testMmapArena()
+	defer a.Free()
+
+	page := os.Getpagesize()
+	a.Bytes(8)
+
+	m := a.Mark()
+	a.Bytes(page)
+	a.ResetTo(m)
+
+	assert.Equal(t, int64(8), a.Len())
+	assert.Len(t, a.heap.free, 1)
+}
+
+// Free
+
+func TestMmapArena_Free__should_unmap_regions(t *testing.T) {
+	a := testMmapArena()
+	a.Bytes(16)
+	a.Bytes(os.Getpagesize())
+
+	a.Free()
+	assert.Len(t, a.heap.regions, 0)
+	assert.Len(t, a.heap.free, 0)
+}
+
+func TestMmapArena_Free__should_not_release_state_to_pool(t *testing.T) {
+	a := testMmapArena()
+	s := a.state
+	a.Bytes(16)
+	a.Free()
+
+	assert.Nil(t, a.state)
+	assert.Same(t, a.heap, s.heap)
+	assert.Panics(t, func() {
+		a.Free()
+	})
+}
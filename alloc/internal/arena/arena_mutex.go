@@ -5,11 +5,13 @@
 package arena
 
 import (
+	"runtime/debug"
 	"sync"
 	"unsafe"
 
 	"github.com/basecomplextech/baselibrary/alloc/internal/heap"
 	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/baselibrary/status"
 )
 
 // MutexArena is a thread-safe arena which uses a mutex to synchronize access.
@@ -27,6 +29,7 @@ var _ Arena = (*mutexArena)(nil)
 type mutexArena struct {
 	mu sync.Mutex
 	*state
+	freed []byte // free stack in debug mode
 }
 
 func newMutexArena(h *heap.Heap) *mutexArena {
@@ -67,6 +70,24 @@ func (a *mutexArena) Bytes(size int) []byte {
 	return a.bytes(size)
 }
 
+// TryAlloc allocates a memory block and returns a pointer to it,
+// or returns an unavailable status if the memory budget is exceeded.
+func (a *mutexArena) TryAlloc(size int) (unsafe.Pointer, status.Status) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.tryAlloc(size)
+}
+
+// TryBytes allocates a byte slice,
+// or returns an unavailable status if the memory budget is exceeded.
+func (a *mutexArena) TryBytes(size int) ([]byte, status.Status) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.tryBytes(size)
+}
+
 // Buffer allocates a buffer in the arena, the buffer cannot be freed.
 func (a *mutexArena) Buffer() buffer.Buffer {
 	b := Alloc[arenaBuffer](a)
@@ -91,6 +112,22 @@ func (a *mutexArena) Reset() {
 	a.reset()
 }
 
+// Mark returns a checkpoint of the arena current state.
+func (a *mutexArena) Mark() Mark {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.mark()
+}
+
+// ResetTo releases all memory allocated after the mark.
+func (a *mutexArena) ResetTo(m Mark) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.resetTo(m)
+}
+
 // Internal
 
 // Free frees the arena and releases its memory.
@@ -99,7 +136,14 @@ func (a *mutexArena) Free() {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
+	if a.state == nil {
+		panicDoubleFree(a.freed)
+	}
+
 	s := a.state
 	a.state = nil
+	if s.heap.IsDebug() {
+		a.freed = debug.Stack()
+	}
 	releaseState(s)
 }
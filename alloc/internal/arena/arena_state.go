@@ -11,17 +11,31 @@ import (
 	"github.com/basecomplextech/baselibrary/collect/sets"
 	"github.com/basecomplextech/baselibrary/opt"
 	"github.com/basecomplextech/baselibrary/pools"
+	"github.com/basecomplextech/baselibrary/status"
 )
 
 type state struct {
-	heap   *heap.Heap
+	heap   blockHeap
 	pooled bool
-	cap    int64 // total allocated capacity
+	cap    int64  // total allocated capacity
+	gen    uint64 // incremented on reset, invalidates marks
 
 	blocks []*heap.Block
 	pinned opt.Opt[sets.Set[any]]
 }
 
+// blockHeap allocates and frees memory blocks, see [heap.Heap].
+type blockHeap interface {
+	// TryAlloc allocates a new block, or returns an unavailable status.
+	TryAlloc(size int) (*heap.Block, status.Status)
+
+	// FreeMany frees multiple blocks.
+	FreeMany(blocks ...*heap.Block)
+
+	// IsDebug returns true if the heap is in the debug mode.
+	IsDebug() bool
+}
+
 // len calculates and returns the number of used bytes.
 func (s *state) len() int64 {
 	n := int64(0)
@@ -31,29 +45,53 @@ func (s *state) len() int64 {
 	return n
 }
 
-// alloc allocates a memory block and returns a pointer to it.
+// alloc allocates a memory block and returns a pointer to it, panics if the budget is exceeded.
 func (s *state) alloc(size int) unsafe.Pointer {
+	ptr, st := s.tryAlloc(size)
+	if !st.OK() {
+		panic(st.ToError())
+	}
+	return ptr
+}
+
+// bytes allocates a byte slice, panics if the budget is exceeded.
+func (s *state) bytes(size int) []byte {
+	b, st := s.tryBytes(size)
+	if !st.OK() {
+		panic(st.ToError())
+	}
+	return b
+}
+
+// tryAlloc allocates a memory block and returns a pointer to it.
+func (s *state) tryAlloc(size int) (unsafe.Pointer, status.Status) {
 	if len(s.blocks) > 0 {
 		b := s.blocks[len(s.blocks)-1]
 
 		ptr := b.Alloc(size)
 		if ptr != nil {
-			return ptr
+			return ptr, status.OK
 		}
 	}
 
-	b := s.allocBlock(size)
-	return b.Alloc(size)
+	b, st := s.allocBlock(size)
+	if !st.OK() {
+		return nil, st
+	}
+	return b.Alloc(size), status.OK
 }
 
-// bytes allocates a byte slice.
-func (s *state) bytes(size int) []byte {
+// tryBytes allocates a byte slice.
+func (s *state) tryBytes(size int) ([]byte, status.Status) {
 	if size == 0 {
-		return nil
+		return nil, status.OK
 	}
 
-	ptr := s.alloc(size)
-	return unsafe.Slice((*byte)(ptr), size)
+	ptr, st := s.tryAlloc(size)
+	if !st.OK() {
+		return nil, st
+	}
+	return unsafe.Slice((*byte)(ptr), size), status.OK
 }
 
 // pin pins an external object to the arena.
@@ -67,9 +105,64 @@ func (s *state) pin(obj any) {
 	set.Add(obj)
 }
 
+// mark returns a checkpoint of the current state.
+func (s *state) mark() Mark {
+	n := len(s.blocks)
+	if n == 0 {
+		return Mark{gen: s.gen}
+	}
+
+	last := s.blocks[n-1]
+	return Mark{
+		gen:    s.gen,
+		blocks: n,
+		len:    last.Len(),
+	}
+}
+
+// resetTo releases all memory allocated after the mark.
+func (s *state) resetTo(m Mark) {
+	// Reset all when empty mark, invalidate other marks
+	if m.blocks == 0 {
+		s.gen++
+		s.resetBlocks()
+		return
+	}
+
+	// Check generation
+	if m.gen != s.gen {
+		panic("arena: invalid mark, arena has been reset")
+	}
+
+	// Check mark after reset to an earlier mark
+	n := m.blocks
+	if n > len(s.blocks) {
+		panic("arena: invalid mark, arena has been reset")
+	}
+	last := s.blocks[n-1]
+	if m.len > last.Len() {
+		panic("arena: invalid mark, arena has been reset")
+	}
+
+	// Truncate last block
+	last.Truncate(m.len)
+
+	// Free next blocks
+	if n == len(s.blocks) {
+		return
+	}
+	for _, b := range s.blocks[n:] {
+		s.cap -= int64(b.Cap())
+	}
+
+	s.heap.FreeMany(s.blocks[n:]...)
+	clear(s.blocks[n:]) // for gc
+	s.blocks = s.blocks[:n]
+}
+
 // private
 
-func (s *state) allocBlock(n int) *heap.Block {
+func (s *state) allocBlock(n int) (*heap.Block, status.Status) {
 	// Double last block capacity
 	size := 0
 	if len(s.blocks) > 0 {
@@ -81,18 +174,29 @@ func (s *state) allocBlock(n int) *heap.Block {
 	}
 
 	// Alloc next block
-	b := s.heap.Alloc(size)
+	b, st := s.heap.TryAlloc(size)
+	if !st.OK() {
+		return nil, st
+	}
+
 	s.blocks = append(s.blocks, b)
 	s.cap += int64(b.Cap())
-	return b
+	return b, status.OK
 }
 
 func (s *state) reset() {
+	// Invalidate marks
+	s.gen++
+
 	// Clear pinned objects
 	if set, ok := s.pinned.Unwrap(); ok {
 		clear(set)
 	}
 
+	s.resetBlocks()
+}
+
+func (s *state) resetBlocks() {
 	// Return if no blocks
 	if len(s.blocks) == 0 {
 		return
@@ -101,9 +205,9 @@ func (s *state) reset() {
 	// Reset capacity
 	s.cap = 0
 
-	// Reset the first block if small
+	// Reset the first block if small, free all blocks in debug mode
 	n := 0
-	if b := s.blocks[0]; b.Cap() == heap.MinBlockSize {
+	if b := s.blocks[0]; b.Cap() == heap.MinBlockSize && !s.heap.IsDebug() {
 		n = 1
 
 		b.Reset()
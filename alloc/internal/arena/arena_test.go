@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/basecomplextech/baselibrary/alloc/internal/heap"
+	"github.com/basecomplextech/baselibrary/status"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -18,6 +19,17 @@ func testArena() *arena {
 	return newArena(h)
 }
 
+func testDebugArena() *arena {
+	h := heap.NewDebug()
+	return newArena(h)
+}
+
+func skipDebug(t *testing.T) {
+	if heap.Debug {
+		t.Skip("Blocks are not reused in debug mode")
+	}
+}
+
 // Acquire
 
 func TestAcquireArena__should_return_pooled_arena(t *testing.T) {
@@ -39,6 +51,7 @@ func TestArena_Free__should_free_arena(t *testing.T) {
 }
 
 func TestArena_Free__should_reset_first_block_other_release_blocks(t *testing.T) {
+	skipDebug(t)
 	a := testArena()
 	a.Alloc(1)
 	a.Alloc(1024)
@@ -67,6 +80,7 @@ func TestArena_Len__should_return_allocated_memory(t *testing.T) {
 // Reset
 
 func TestArena_Reset__should_reset_first_free_other_blocks(t *testing.T) {
+	skipDebug(t)
 	a := testArena()
 
 	a.Alloc(16)
@@ -83,6 +97,7 @@ func TestArena_Reset__should_reset_first_free_other_blocks(t *testing.T) {
 }
 
 func TestArena_Reset__should_free_blocks_except_for_first_when_small(t *testing.T) {
+	skipDebug(t)
 	a := testArena()
 
 	a.Alloc(1024)
@@ -149,3 +164,179 @@ func TestArena_allocBlock__should_increment_capacity(t *testing.T) {
 	cp += a.blocks[1].Cap()
 	assert.Equal(t, int64(cp), a.cap)
 }
+
+// TryAlloc
+
+func TestArena_TryAlloc__should_return_error_when_budget_exceeded(t *testing.T) {
+	h := heap.New()
+	h.SetBudget(heap.Budget{HardLimit: heap.MinBlockSize})
+
+	a := newArena(h)
+	defer a.Free()
+
+	_, st := a.TryAlloc(heap.MinBlockSize)
+	require.True(t, st.OK())
+
+	_, st = a.TryAlloc(1)
+	assert.Equal(t, status.CodeUnavailable, st.Code)
+	assert.Len(t, a.blocks, 1)
+}
+
+func TestArena_TryBytes__should_return_error_when_budget_exceeded(t *testing.T) {
+	h := heap.New()
+	h.SetBudget(heap.Budget{HardLimit: heap.MinBlockSize})
+
+	a := newArena(h)
+	defer a.Free()
+
+	cp := a.Cap()
+	_, st := a.TryBytes(heap.MinBlockSize + 1)
+	assert.Equal(t, status.CodeUnavailable, st.Code)
+	assert.Equal(t, cp, a.Cap())
+}
+
+// ResetTo
+
+func TestArena_ResetTo__should_truncate_last_block(t *testing.T) {
+	a := testArena()
+	a.Alloc(16)
+
+	m := a.Mark()
+	a.Alloc(32)
+	require.Len(t, a.blocks, 1)
+
+	a.ResetTo(m)
+	assert.Len(t, a.blocks, 1)
+	assert.Equal(t, int64(16), a.Len())
+}
+
+func TestArena_ResetTo__should_free_blocks_after_mark(t *testing.T) {
+	a := testArena()
+	a.Alloc(16)
+
+	m := a.Mark()
+	a.Alloc(1024)
+	a.Alloc(4096)
+	require.Len(t, a.blocks, 3)
+
+	b := a.blocks[0]
+	a.ResetTo(m)
+
+	assert.Len(t, a.blocks, 1)
+	assert.Equal(t, int64(b.Cap()), a.cap)
+	assert.Equal(t, int64(16), a.Len())
+}
+
+func TestArena_ResetTo__should_zero_released_memory(t *testing.T) {
+	a := testArena()
+	a.Alloc(8)
+
+	m := a.Mark()
+	p := a.Bytes(8)
+	copy(p, "abcdefgh")
+
+	a.ResetTo(m)
+	p1 := a.Bytes(8)
+	assert.Equal(t, make([]byte, 8), p1)
+}
+
+func TestArena_ResetTo__should_reset_arena_when_empty_mark(t *testing.T) {
+	skipDebug(t)
+	a := testArena()
+
+	m := a.Mark()
+	a.Alloc(16)
+	a.Alloc(1024)
+	a.Alloc(4096)
+
+	a.ResetTo(m)
+	assert.Len(t, a.blocks, 1)
+	assert.Equal(t, int64(0), a.Len())
+}
+
+func TestArena_ResetTo__should_invalidate_marks_when_empty_mark(t *testing.T) {
+	a := testArena()
+
+	m0 := a.Mark()
+	a.Alloc(16)
+	m1 := a.Mark()
+	a.Alloc(16)
+
+	a.ResetTo(m0)
+	a.Alloc(32)
+
+	assert.Panics(t, func() {
+		a.ResetTo(m1)
+	})
+}
+
+func TestArena_ResetTo__should_reuse_empty_mark(t *testing.T) {
+	a := testArena()
+	m := a.Mark()
+
+	a.Alloc(16)
+	a.ResetTo(m)
+	a.Alloc(16)
+	a.ResetTo(m)
+	assert.Equal(t, int64(0), a.Len())
+}
+
+func TestArena_ResetTo__should_panic_on_invalid_mark(t *testing.T) {
+	a := testArena()
+	a.Alloc(16)
+	a.Alloc(1024)
+
+	m := a.Mark()
+	a.Reset()
+
+	assert.Panics(t, func() {
+		a.ResetTo(m)
+	})
+}
+
+func TestArena_ResetTo__should_panic_on_mark_before_reset_after_new_allocations(t *testing.T) {
+	a := testArena()
+	a.Alloc(16)
+
+	m := a.Mark()
+	a.Reset()
+	a.Alloc(16)
+	a.Alloc(1024)
+
+	assert.Panics(t, func() {
+		a.ResetTo(m)
+	})
+}
+
+// Debug
+
+func TestArena_Reset__should_poison_memory_in_debug_mode(t *testing.T) {
+	a := testDebugArena()
+	p := a.Bytes(16)
+	copy(p, "hello, world")
+
+	a.Reset()
+	assert.True(t, heap.IsPoisoned(p))
+	assert.Len(t, a.blocks, 0)
+}
+
+func TestArena_ResetTo__should_poison_released_blocks_in_debug_mode(t *testing.T) {
+	a := testDebugArena()
+	a.Alloc(16)
+
+	m := a.Mark()
+	p := a.Bytes(2048)
+	a.ResetTo(m)
+
+	assert.True(t, heap.IsPoisoned(p))
+}
+
+func TestArena_Free__should_panic_on_double_free_in_debug_mode(t *testing.T) {
+	a := testDebugArena()
+	a.Alloc(16)
+	a.Free()
+
+	assert.Panics(t, func() {
+		a.Free()
+	})
+}
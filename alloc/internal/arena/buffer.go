@@ -4,7 +4,10 @@
 
 package arena
 
-import "github.com/basecomplextech/baselibrary/buffer"
+import (
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/baselibrary/status"
+)
 
 var _ buffer.Buffer = (*arenaBuffer)(nil)
 
@@ -30,48 +33,45 @@ func (b *arenaBuffer) Bytes() []byte {
 
 // Grow grows the buffer and returns an n-byte slice.
 // It be should be used directly and is only valid until the next buffer mutation.
+// The method panics if the memory budget is exceeded.
 //
 // Usage:
 //
 //	p := b.Grow(8)
 //	binary.BigEndian.PutUint64(p, 1234)
 func (b *arenaBuffer) Grow(n int) []byte {
-	cp := cap(b.buf)
-	ln := len(b.buf)
-
-	// Realloc
-	free := cp - ln
-	if free < n {
-		size := (cp * 2) + n
-		buf := b.arena.Bytes(size)[:ln:size]
-		copy(buf, b.buf)
-		b.buf = buf
+	p, st := b.tryGrow(n)
+	if !st.OK() {
+		panic(st.ToError())
 	}
-
-	// Grow buffer
-	size := ln + n
-	b.buf = b.buf[:size]
-
-	// Return slice
-	return b.buf[ln:size]
+	return p
 }
 
-// Write appends bytes from p to the buffer.
+// Write appends bytes from p to the buffer,
+// returns an unavailable error if the memory budget is exceeded.
 //
 // Equivalent to:
 //
 //	buf := b.Grow(n)
 //	copy(buf, p)
 func (b *arenaBuffer) Write(p []byte) (n int, err error) {
-	buf := b.Grow(len(p))
+	buf, st := b.tryGrow(len(p))
+	if !st.OK() {
+		return 0, st.ToError()
+	}
 
 	n = copy(buf, p)
 	return
 }
 
-// WriteByte writes a byte to the buffer.
+// WriteByte writes a byte to the buffer,
+// returns an unavailable error if the memory budget is exceeded.
 func (b *arenaBuffer) WriteByte(v byte) error {
-	buf := b.Grow(1)
+	buf, st := b.tryGrow(1)
+	if !st.OK() {
+		return st.ToError()
+	}
+
 	buf[0] = v
 	return nil
 }
@@ -80,3 +80,31 @@ func (b *arenaBuffer) WriteByte(v byte) error {
 func (b *arenaBuffer) Reset() {
 	b.buf = b.buf[:0]
 }
+
+// private
+
+func (b *arenaBuffer) tryGrow(n int) ([]byte, status.Status) {
+	cp := cap(b.buf)
+	ln := len(b.buf)
+
+	// Realloc
+	free := cp - ln
+	if free < n {
+		size := (cp * 2) + n
+		buf, st := b.arena.TryBytes(size)
+		if !st.OK() {
+			return nil, st
+		}
+
+		buf = buf[:ln:size]
+		copy(buf, b.buf)
+		b.buf = buf
+	}
+
+	// Grow buffer
+	size := ln + n
+	b.buf = b.buf[:size]
+
+	// Return slice
+	return b.buf[ln:size], status.OK
+}
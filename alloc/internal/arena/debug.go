@@ -0,0 +1,20 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package arena
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// panicDoubleFree panics with the free stack when recorded in the debug mode.
+func panicDoubleFree(freed []byte) {
+	if freed == nil {
+		panic("arena: double free")
+	}
+
+	panic(fmt.Sprintf("arena: double free\n\nfreed at:\n%s\ndouble freed at:\n%s",
+		freed, debug.Stack()))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/basecomplextech/baselibrary/buffer"
 	"github.com/basecomplextech/baselibrary/collect/slices2"
 	"github.com/basecomplextech/baselibrary/pools"
+	"github.com/basecomplextech/baselibrary/status"
 )
 
 // Buffer is a byte buffer, which internally allocates memory in blocks.
@@ -59,10 +60,11 @@ type state struct {
 	heap   *heap.Heap
 	pooled bool
 
-	init int // initial capacity
-	len  int // total length in bytes
-
+	rope   bool // never merge blocks on writes
+	init   int  // initial capacity
+	len    int  // total length in bytes
 	blocks []*heap.Block
+	chunks [][]byte // last returned chunks
 }
 
 func newBuffer(h *heap.Heap) *bufferImpl {
@@ -72,9 +74,7 @@ func newBuffer(h *heap.Heap) *bufferImpl {
 func newBufferSize(heap *heap.Heap, size int) *bufferImpl {
 	b := &bufferImpl{acquireState()}
 	b.heap = heap
-	if size > 0 {
-		b.init = b.allocBlock(size).Cap()
-	}
+	b.initBlock(size)
 	return b
 }
 
@@ -109,6 +109,7 @@ func (b *bufferImpl) Rem() int {
 
 // Bytes returns a byte slice with the buffer bytes.
 // It is valid for use only until the next buffer mutation.
+// The method panics if the memory budget is exceeded when merging blocks.
 func (b *bufferImpl) Bytes() []byte {
 	if len(b.blocks) == 0 {
 		return nil
@@ -119,44 +120,70 @@ func (b *bufferImpl) Bytes() []byte {
 }
 
 // Grow grows the buffer and returns an n-byte slice.
+// The method panics if the memory budget is exceeded.
 func (b *bufferImpl) Grow(n int) []byte {
-	last := b.last()
-	if last == nil || last.Rem() < n {
-		last = b.allocBlock(n)
+	p, st := b.tryGrow(n)
+	if !st.OK() {
+		panic(st.ToError())
 	}
-
-	p := last.Grow(n)
-	b.len += n
 	return p
 }
 
-// Write appends bytes from p to the buffer.
+// Write appends bytes from p to the buffer,
+// returns an unavailable error if the memory budget is exceeded.
 func (b *bufferImpl) Write(p []byte) (n int, err error) {
-	q := b.Grow(len(p))
+	if b.rope {
+		return writeChunks(b, p)
+	}
+
+	q, st := b.tryGrow(len(p))
+	if !st.OK() {
+		return 0, st.ToError()
+	}
+
 	n = copy(q, p)
 	return
 }
 
-// WriteByte writes a byte to the buffer.
+// WriteByte writes a byte to the buffer,
+// returns an unavailable error if the memory budget is exceeded.
 func (b *bufferImpl) WriteByte(c byte) error {
-	q := b.Grow(1)
+	q, st := b.tryGrow(1)
+	if !st.OK() {
+		return st.ToError()
+	}
+
 	q[0] = c
 	return nil
 }
 
-// WriteRune writes a rune to the buffer.
+// WriteRune writes a rune to the buffer,
+// returns an unavailable error if the memory budget is exceeded.
 func (b *bufferImpl) WriteRune(r rune) (n int, err error) {
 	p := [utf8.UTFMax]byte{}
 	n = utf8.EncodeRune(p[:], r)
 
-	q := b.Grow(n)
+	q, st := b.tryGrow(n)
+	if !st.OK() {
+		return 0, st.ToError()
+	}
+
 	copy(q, p[:n])
 	return
 }
 
-// WriteString writes a string to the buffer.
+// WriteString writes a string to the buffer,
+// returns an unavailable error if the memory budget is exceeded.
 func (b *bufferImpl) WriteString(s string) (n int, err error) {
-	q := b.Grow(len(s))
+	if b.rope {
+		return writeChunks(b, s)
+	}
+
+	q, st := b.tryGrow(len(s))
+	if !st.OK() {
+		return 0, st.ToError()
+	}
+
 	n = copy(q, s)
 	return
 }
@@ -176,6 +203,22 @@ func (b *bufferImpl) last() *heap.Block {
 	return b.blocks[len(b.blocks)-1]
 }
 
+// tryGrow grows the buffer and returns an n-byte slice.
+func (b *bufferImpl) tryGrow(n int) ([]byte, status.Status) {
+	last := b.last()
+	if last == nil || last.Rem() < n {
+		var st status.Status
+		last, st = b.allocBlock(n)
+		if !st.OK() {
+			return nil, st
+		}
+	}
+
+	p := last.Grow(n)
+	b.len += n
+	return p, status.OK
+}
+
 // merge merges multiple blocks into a single one.
 func (b *bufferImpl) merge() {
 	if len(b.blocks) <= 1 {
@@ -219,8 +262,20 @@ func (b *bufferImpl) reset() {
 
 // blocks
 
+// initBlock allocates the initial block, an allocation error is returned on the next write.
+func (b *bufferImpl) initBlock(size int) {
+	if size <= 0 {
+		return
+	}
+
+	block, st := b.allocBlock(size)
+	if st.OK() {
+		b.init = block.Cap()
+	}
+}
+
 // allocBlock allocates the next block.
-func (b *bufferImpl) allocBlock(n int) *heap.Block {
+func (b *bufferImpl) allocBlock(n int) (*heap.Block, status.Status) {
 	// Use initial size or double last block capacity
 	size := 0
 	if len(b.blocks) == 0 {
@@ -229,13 +284,20 @@ func (b *bufferImpl) allocBlock(n int) *heap.Block {
 		last := b.blocks[len(b.blocks)-1]
 		size = last.Cap() * 2
 	}
+	if b.rope && size > ropeChunkSize {
+		size = ropeChunkSize
+	}
 	if n > size {
 		size = n
 	}
 
-	block := b.heap.Alloc(size)
+	block, st := b.heap.TryAlloc(size)
+	if !st.OK() {
+		return nil, st
+	}
+
 	b.blocks = append(b.blocks, block)
-	return block
+	return block, status.OK
 }
 
 // freeBlocks clears and frees the blocks.
@@ -283,7 +345,9 @@ func releaseState(s *state) {
 
 func (s *state) reset() {
 	blocks := slices2.Truncate(s.blocks)
+	chunks := slices2.Truncate(s.chunks)
 
 	*s = state{}
 	s.blocks = blocks
+	s.chunks = chunks
 }
@@ -110,6 +110,31 @@ func TestBuffer_Write__should_append_bytes(t *testing.T) {
 	assert.Equal(t, len(data), n)
 }
 
+func TestBuffer_Write__should_return_error_when_budget_exceeded(t *testing.T) {
+	h := heap.New()
+	h.SetBudget(heap.Budget{HardLimit: heap.MinBlockSize})
+
+	b := newBuffer(h)
+	defer b.Free()
+
+	_, err := b.Write(make([]byte, heap.MinBlockSize+1))
+	require.Error(t, err)
+	assert.Equal(t, 0, b.Len())
+}
+
+func TestBuffer_Write__should_write_when_initial_block_exceeded_budget(t *testing.T) {
+	h := heap.New()
+	h.SetBudget(heap.Budget{HardLimit: heap.MinBlockSize})
+
+	b := newBufferSize(h, heap.MinBlockSize*2)
+	defer b.Free()
+	require.Empty(t, b.blocks)
+
+	_, err := b.WriteString("hello")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b.Bytes()))
+}
+
 // Reset
 
 func TestBuffer_Reset__should_free_blocks(t *testing.T) {
@@ -0,0 +1,169 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package buffer
+
+import (
+	"errors"
+	"io"
+	"net"
+
+	"github.com/basecomplextech/baselibrary/alloc/internal/heap"
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// Rope is a chunked buffer which never merges its chunks on writes,
+// and allows to read and write them without copying.
+//
+// Rope.Bytes still merges the chunks into a single one, use Chunks, ReadAt or WriteTo instead.
+type Rope interface {
+	Buffer
+	io.ReaderAt
+	io.WriterTo
+
+	// ByteAt returns a byte at an offset, panics if the offset is out of range.
+	ByteAt(off int) byte
+
+	// Chunks returns the buffer chunks.
+	// The chunks are valid for use only until the next buffer mutation.
+	Chunks() [][]byte
+
+	// Buffers returns the buffer chunks as net.Buffers, writing them to a connection uses writev.
+	// The buffers are valid for use only until the next buffer mutation.
+	Buffers() net.Buffers
+}
+
+// NewRope returns a new rope buffer.
+func NewRope() Rope {
+	return newRopeSize(heap.Global, heap.MinBlockSize)
+}
+
+// NewRopeSize returns a new rope buffer with a preallocated memory storage.
+func NewRopeSize(size int) Rope {
+	return newRopeSize(heap.Global, size)
+}
+
+// internal
+
+var _ Rope = (*bufferImpl)(nil)
+
+// ropeChunkSize is the max size of rope chunks, larger chunks can still be allocated
+// to fit large Grow calls.
+const ropeChunkSize = 1 << 16 // 64K
+
+func newRope(h *heap.Heap) *bufferImpl {
+	return newRopeSize(h, heap.MinBlockSize)
+}
+
+func newRopeSize(h *heap.Heap, size int) *bufferImpl {
+	b := &bufferImpl{acquireState()}
+	b.heap = h
+	b.rope = true
+	b.initBlock(size)
+	return b
+}
+
+// ByteAt returns a byte at an offset, panics if the offset is out of range.
+func (b *bufferImpl) ByteAt(off int) byte {
+	if off < 0 || off >= b.len {
+		panic("buffer: offset out of range")
+	}
+
+	for _, block := range b.blocks {
+		p := block.Bytes()
+		if off < len(p) {
+			return p[off]
+		}
+		off -= len(p)
+	}
+	panic("unreachable")
+}
+
+// ReadAt reads len(p) bytes starting at an offset into p, possibly across multiple chunks.
+// The method returns io.EOF when fewer bytes are read.
+func (b *bufferImpl) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.New("buffer: negative offset")
+	}
+
+	// Skip chunks before offset
+	i := 0
+	rem := int(off)
+	for ; i < len(b.blocks); i++ {
+		size := b.blocks[i].Len()
+		if rem < size {
+			break
+		}
+		rem -= size
+	}
+
+	// Copy chunks
+	for ; i < len(b.blocks) && n < len(p); i++ {
+		chunk := b.blocks[i].Bytes()
+		n += copy(p[n:], chunk[rem:])
+		rem = 0
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Chunks returns the buffer chunks.
+// The chunks are valid for use only until the next buffer mutation.
+func (b *bufferImpl) Chunks() [][]byte {
+	clear(b.chunks)
+	b.chunks = b.chunks[:0]
+
+	for _, block := range b.blocks {
+		p := block.Bytes()
+		if len(p) == 0 {
+			continue
+		}
+		b.chunks = append(b.chunks, p)
+	}
+	return b.chunks
+}
+
+// Buffers returns the buffer chunks as net.Buffers, writing them to a connection uses writev.
+// The buffers are valid for use only until the next buffer mutation.
+func (b *bufferImpl) Buffers() net.Buffers {
+	return net.Buffers(b.Chunks())
+}
+
+// WriteTo writes the buffer chunks to a writer, uses writev when the writer is a connection.
+func (b *bufferImpl) WriteTo(w io.Writer) (n int64, err error) {
+	bufs := b.Buffers()
+	return bufs.WriteTo(w)
+}
+
+// private
+
+// writeChunks appends bytes to the last chunk and the next ones, the function does not merge
+// or grow the chunks to fit the bytes. The function returns the number of written bytes,
+// and an unavailable error if the memory budget is exceeded.
+func writeChunks[S []byte | string](b *bufferImpl, p S) (int, error) {
+	written := 0
+
+	for len(p) > 0 {
+		last := b.last()
+		if last == nil || last.Rem() == 0 {
+			var st status.Status
+			last, st = b.allocBlock(1)
+			if !st.OK() {
+				return written, st.ToError()
+			}
+		}
+
+		n := min(len(p), last.Rem())
+		q := last.Grow(n)
+		copy(q, p)
+
+		b.len += n
+		written += n
+		p = p[n:]
+	}
+	return written, nil
+}
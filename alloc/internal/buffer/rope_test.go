@@ -0,0 +1,180 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package buffer
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/alloc/internal/heap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRope() *bufferImpl {
+	h := heap.New()
+	return newRope(h)
+}
+
+func testRopeData(n int) []byte {
+	data := make([]byte, n)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	return data
+}
+
+// Write
+
+func TestRope_Write__should_not_merge_chunks(t *testing.T) {
+	b := testRope()
+	data := testRopeData(heap.MinBlockSize * 5)
+
+	b.Write(data)
+	require.Greater(t, len(b.blocks), 1)
+
+	chunks := b.Chunks()
+	assert.Len(t, chunks, len(b.blocks))
+	assert.Equal(t, data, bytes.Join(chunks, nil))
+}
+
+func TestRope_Write__should_fill_last_chunk_before_allocating_next(t *testing.T) {
+	b := testRope()
+	data := testRopeData(heap.MinBlockSize + 1)
+
+	b.Write(data)
+	require.Len(t, b.blocks, 2)
+
+	assert.Equal(t, heap.MinBlockSize, b.blocks[0].Len())
+	assert.Equal(t, 1, b.blocks[1].Len())
+}
+
+func TestRope_Write__should_limit_chunk_size(t *testing.T) {
+	b := testRope()
+	data := testRopeData(ropeChunkSize * 4)
+
+	b.Write(data)
+
+	for _, block := range b.blocks {
+		assert.LessOrEqual(t, block.Cap(), ropeChunkSize)
+	}
+	assert.Equal(t, data, bytes.Join(b.Chunks(), nil))
+}
+
+func TestRope_Write__should_return_written_bytes_when_budget_exceeded(t *testing.T) {
+	h := heap.New()
+	h.SetBudget(heap.Budget{HardLimit: heap.MinBlockSize})
+
+	b := newRope(h)
+	defer b.Free()
+
+	n, err := b.Write(testRopeData(heap.MinBlockSize + 1))
+	require.Error(t, err)
+	assert.Equal(t, heap.MinBlockSize, n)
+	assert.Equal(t, heap.MinBlockSize, b.Len())
+}
+
+// ByteAt
+
+func TestRope_ByteAt__should_return_byte_across_chunks(t *testing.T) {
+	b := testRope()
+	data := testRopeData(heap.MinBlockSize * 5)
+	b.Write(data)
+
+	for i := range data {
+		require.Equal(t, data[i], b.ByteAt(i))
+	}
+}
+
+func TestRope_ByteAt__should_panic_when_out_of_range(t *testing.T) {
+	b := testRope()
+	b.Write([]byte("hello"))
+
+	assert.Panics(t, func() {
+		b.ByteAt(5)
+	})
+}
+
+// ReadAt
+
+func TestRope_ReadAt__should_read_across_chunks(t *testing.T) {
+	b := testRope()
+	data := testRopeData(heap.MinBlockSize * 5)
+	b.Write(data)
+
+	off := heap.MinBlockSize - 10
+	p := make([]byte, heap.MinBlockSize*2)
+
+	n, err := b.ReadAt(p, int64(off))
+	require.NoError(t, err)
+	assert.Equal(t, len(p), n)
+	assert.Equal(t, data[off:off+len(p)], p)
+	assert.Len(t, b.blocks, 3)
+}
+
+func TestRope_ReadAt__should_return_eof_on_short_read(t *testing.T) {
+	b := testRope()
+	b.Write([]byte("hello, world"))
+
+	p := make([]byte, 10)
+	n, err := b.ReadAt(p, 7)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, []byte("world"), p[:n])
+}
+
+// WriteTo
+
+func TestRope_WriteTo__should_write_chunks(t *testing.T) {
+	b := testRope()
+	data := testRopeData(heap.MinBlockSize * 5)
+	b.Write(data)
+
+	var out bytes.Buffer
+	n, err := b.WriteTo(&out)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(len(data)), n)
+	assert.Equal(t, data, out.Bytes())
+}
+
+func TestRope_WriteTo__should_write_chunks_to_connection(t *testing.T) {
+	b := testRope()
+	data := testRopeData(heap.MinBlockSize * 5)
+	b.Write(data)
+
+	c0, c1 := net.Pipe()
+	defer c0.Close()
+	defer c1.Close()
+
+	done := make(chan []byte)
+	go func() {
+		out, _ := io.ReadAll(c1)
+		done <- out
+	}()
+
+	_, err := b.WriteTo(c0)
+	require.NoError(t, err)
+	c0.Close()
+
+	out := <-done
+	assert.Equal(t, data, out)
+}
+
+// Buffers
+
+func TestRope_Buffers__should_not_consume_chunks(t *testing.T) {
+	b := testRope()
+	data := testRopeData(heap.MinBlockSize * 3)
+	b.Write(data)
+
+	var out bytes.Buffer
+	bufs := b.Buffers()
+	bufs.WriteTo(&out)
+
+	assert.Equal(t, data, bytes.Join(b.Chunks(), nil))
+}
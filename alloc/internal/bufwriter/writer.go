@@ -6,11 +6,17 @@ package bufwriter
 
 import (
 	"io"
+	"sync"
+	"time"
 
 	"github.com/basecomplextech/baselibrary/alloc/internal/buffer"
+	"github.com/basecomplextech/baselibrary/status"
 )
 
 // Writer buffers small writes and flushes them to an underlying writer.
+//
+// The writer is not thread-safe by default. Writes and flushes are serialized only when
+// timed flushes or the synchronized option are enabled.
 type Writer interface {
 	io.Writer
 
@@ -20,12 +26,24 @@ type Writer interface {
 	// Flush writes any buffered data to the underlying writer.
 	Flush() error
 
+	// OnCancelled flushes buffered data, errors are returned by the next write or flush.
+	//
+	// The method allows to use the writer as an async context callback, which flushes
+	// the data when the context is cancelled. The writer must be synchronized in this case,
+	// and must be removed from the context before it is freed.
+	//
+	//	w := bufwriter.NewOptions(conn, bufwriter.Options{Synchronized: true})
+	//	ctx.AddCallback(w)
+	//	defer ctx.RemoveCallback(w)
+	OnCancelled(st status.Status)
+
 	// Reset discards the unwritten data, clears the error and sets a new destination.
 	Reset(io.Writer)
 
 	// Internal
 
 	// Free frees the writer, releases its internal resources.
+	// The writer must be removed from context callbacks before Free.
 	Free()
 }
 
@@ -41,14 +59,52 @@ func NewSize(dst io.Writer, size int) Writer {
 	return newWriter(dst, buf)
 }
 
+// Options specifies buffered writer options.
+type Options struct {
+	// Size is the buffer size, zero means the default size.
+	Size int
+
+	// MaxDelay is the max time data can stay in the buffer before it is flushed
+	// by a background timer, zero disables timed flushes.
+	//
+	// Errors of timed flushes are returned by the next write or flush.
+	// Timed flushes synchronize the writer.
+	MaxDelay time.Duration
+
+	// Synchronized serializes writes and flushes with a mutex,
+	// so that the writer can be flushed from other goroutines.
+	Synchronized bool
+}
+
+// NewOptions returns a new buffered writer with the specified options.
+func NewOptions(dst io.Writer, opts Options) Writer {
+	size := opts.Size
+	if size <= 0 {
+		size = defaultSize
+	}
+
+	buf := buffer.NewSize(size)
+	w := newWriter(dst, buf)
+	w.delay = opts.MaxDelay
+	w.sync = opts.Synchronized || opts.MaxDelay > 0
+	return w
+}
+
 // internal
 
 const defaultSize = 4096
 
 type writer struct {
-	dst io.Writer
-	buf buffer.Buffer
-	err error
+	mu   sync.Mutex
+	sync bool // lock mu, immutable
+	dst  io.Writer
+	buf  buffer.Buffer
+	err  error
+
+	// timed flush
+	delay time.Duration
+	timer *time.Timer
+	armed bool // timer is running
 }
 
 func newWriter(dst io.Writer, buf buffer.Buffer) *writer {
@@ -60,23 +116,53 @@ func newWriter(dst io.Writer, buf buffer.Buffer) *writer {
 
 // Len returns the number of buffered bytes.
 func (w *writer) Len() int {
+	w.lock()
+	defer w.unlock()
+
 	return w.buf.Len()
 }
 
 // Flush writes any buffered data to the underlying writer.
 func (w *writer) Flush() error {
+	w.lock()
+	defer w.unlock()
+
 	return w.flush()
 }
 
+// OnCancelled flushes buffered data, errors are returned by the next write or flush.
+// The method panics if the writer is not synchronized.
+func (w *writer) OnCancelled(st status.Status) {
+	if !w.sync {
+		panic("bufwriter: writer is not synchronized")
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.buf == nil {
+		return
+	}
+	w.flush() // error is stored
+}
+
 // Reset discards the unwritten data, clears the error and sets a new destination.
 func (w *writer) Reset(dst io.Writer) {
+	w.lock()
+	defer w.unlock()
+
 	w.dst = dst
 	w.buf.Reset()
 	w.err = nil
+	w.stopTimer()
 }
 
 // Write writes len(p) bytes from p to the buffer, flushes the buffer if required.
 func (w *writer) Write(p []byte) (int, error) {
+	w.lock()
+	defer w.unlock()
+	defer w.startTimer()
+
 	var n int
 
 	for len(p) > 0 {
@@ -109,7 +195,12 @@ func (w *writer) Write(p []byte) (int, error) {
 // Internal
 
 // Free frees the writer, releases its internal resources.
+// The writer must be removed from context callbacks before Free.
 func (w *writer) Free() {
+	w.lock()
+	defer w.unlock()
+
+	w.stopTimer()
 	if w.buf != nil {
 		b := w.buf
 		w.buf = nil
@@ -119,7 +210,21 @@ func (w *writer) Free() {
 
 // private
 
+func (w *writer) lock() {
+	if w.sync {
+		w.mu.Lock()
+	}
+}
+
+func (w *writer) unlock() {
+	if w.sync {
+		w.mu.Unlock()
+	}
+}
+
 func (w *writer) flush() error {
+	w.stopTimer()
+
 	if w.err != nil {
 		return w.err
 	}
@@ -137,3 +242,49 @@ func (w *writer) flush() error {
 	w.buf.Reset()
 	return nil
 }
+
+// timer
+
+// startTimer starts the flush timer if there is buffered data.
+func (w *writer) startTimer() {
+	switch {
+	case w.delay <= 0:
+		return
+	case w.armed:
+		return
+	case w.buf == nil || w.buf.Len() == 0:
+		return
+	}
+
+	w.armed = true
+	if w.timer == nil {
+		w.timer = time.AfterFunc(w.delay, w.onTimer)
+	} else {
+		w.timer.Reset(w.delay)
+	}
+}
+
+// stopTimer stops the flush timer if running.
+func (w *writer) stopTimer() {
+	if !w.armed {
+		return
+	}
+
+	w.armed = false
+	w.timer.Stop()
+}
+
+func (w *writer) onTimer() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.armed {
+		return // stopped concurrently
+	}
+
+	w.armed = false
+	if w.buf == nil {
+		return
+	}
+	w.flush() // error is stored
+}
@@ -6,10 +6,16 @@ package bufwriter
 
 import (
 	"crypto/rand"
+	"io"
 	mrand "math/rand/v2"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/basecomplextech/baselibrary/async"
 	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -82,3 +88,125 @@ func TestWriter__should_buffer_small_writes(t *testing.T) {
 	data1 := dst.Bytes()
 	require.Equal(t, data, data1)
 }
+
+// MaxDelay
+
+func TestWriter__should_flush_buffer_after_max_delay(t *testing.T) {
+	dst := &testDst{}
+	w := NewOptions(dst, Options{MaxDelay: 10 * time.Millisecond})
+	defer w.Free()
+
+	_, err := w.Write([]byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, dst.bytes())
+
+	require.Eventually(t, func() bool {
+		return string(dst.bytes()) == "hello"
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 0, w.Len())
+}
+
+func TestWriter__should_not_start_timer_when_buffer_empty(t *testing.T) {
+	dst := &testDst{}
+	w := NewOptions(dst, Options{MaxDelay: 10 * time.Millisecond}).(*writer)
+	defer w.Free()
+
+	_, err := w.Write([]byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	assert.False(t, w.armed)
+}
+
+func TestWriter__should_return_timed_flush_error_on_next_write(t *testing.T) {
+	dst := &testDst{err: io.ErrClosedPipe}
+	w := NewOptions(dst, Options{MaxDelay: time.Millisecond})
+	defer w.Free()
+
+	_, err := w.Write([]byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	require.Eventually(t, func() bool {
+		return w.Flush() == io.ErrClosedPipe
+	}, time.Second, time.Millisecond)
+}
+
+// OnCancelled
+
+func TestWriter_OnCancelled__should_flush_buffer_on_context_cancel(t *testing.T) {
+	dst := &testDst{}
+	w := NewOptions(dst, Options{Synchronized: true})
+	defer w.Free()
+
+	ctx := async.NewContext()
+	defer ctx.Free()
+	ctx.AddCallback(w)
+
+	_, err := w.Write([]byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, dst.bytes())
+
+	ctx.Cancel()
+	assert.Equal(t, "hello", string(dst.bytes()))
+}
+
+func TestWriter_OnCancelled__should_panic_when_not_synchronized(t *testing.T) {
+	w := New(&testDst{})
+	defer w.Free()
+
+	assert.Panics(t, func() {
+		w.OnCancelled(status.Cancelled)
+	})
+}
+
+// Lock
+
+func TestWriter__should_not_lock_without_max_delay(t *testing.T) {
+	w := New(&testDst{}).(*writer)
+	defer w.Free()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, err := w.Write([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, 5, w.Len())
+}
+
+// private
+
+type testDst struct {
+	mu  sync.Mutex
+	buf []byte
+	err error
+}
+
+func (d *testDst) Write(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.err != nil {
+		return 0, d.err
+	}
+	d.buf = append(d.buf, p...)
+	return len(p), nil
+}
+
+func (d *testDst) bytes() []byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.buf
+}
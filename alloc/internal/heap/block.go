@@ -4,7 +4,10 @@
 
 package heap
 
-import "unsafe"
+import (
+	"runtime/debug"
+	"unsafe"
+)
 
 const (
 	MinBlockSize = 1 << minIndex
@@ -12,7 +15,8 @@ const (
 )
 
 type Block struct {
-	buf []byte
+	buf   []byte
+	debug *blockDebug // only in debug mode
 }
 
 func newBlock(size int) *Block {
@@ -21,6 +25,12 @@ func newBlock(size int) *Block {
 	}
 }
 
+// WrapBlock returns a block backed by an external zeroed memory.
+// The block is not pooled and must not be freed by a heap.
+func WrapBlock(buf []byte) *Block {
+	return &Block{buf: buf[:0]}
+}
+
 // Cap returns the block capacity in bytes.
 func (b *Block) Cap() int {
 	return cap(b.buf)
@@ -43,17 +53,36 @@ func (b *Block) Bytes() []byte {
 
 // Reset resets the block.
 func (b *Block) Reset() {
+	if b.debug != nil {
+		b.checkFreed()
+		b.debug.resetStack = debug.Stack()
+	}
 	b.reset()
 }
 
+// Truncate truncates the block to n bytes and zeroes out the released bytes.
+func (b *Block) Truncate(n int) {
+	if n < 0 || n > len(b.buf) {
+		panic("heap: truncate out of range")
+	}
+	if b.debug != nil {
+		b.checkFreed()
+	}
+
+	clear(b.buf[n:])
+	b.buf = b.buf[:n]
+}
+
 // Alloc
 
 const alignment = 8
 
-// Alloc returns an aligned byte slice or nil.
-//
-// inlineable
+// Alloc returns an aligned byte slice or nil, panics on a freed block in the debug mode.
 func (b *Block) Alloc(size int) unsafe.Pointer {
+	if b.debug != nil {
+		b.checkFreed()
+	}
+
 	// Handle zero size
 	if size == 0 {
 		size = 1
@@ -80,6 +109,10 @@ func (b *Block) Alloc(size int) unsafe.Pointer {
 
 // Grow grows the buffer and allocates a byte slice.
 func (b *Block) Grow(size int) []byte {
+	if b.debug != nil {
+		b.checkFreed()
+	}
+
 	free := cap(b.buf) - len(b.buf)
 	if free < size {
 		return nil
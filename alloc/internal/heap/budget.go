@@ -0,0 +1,183 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package heap
+
+import (
+	"sync/atomic"
+
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// Budget specifies a memory budget for heap blocks.
+//
+// Example:
+//
+//	overloaded := async.UnsetFlag()
+//
+//	heap.Global.SetBudget(heap.Budget{
+//		SoftLimit:   1 << 30,
+//		HardLimit:   2 << 30,
+//		OnSoftLimit: overloaded.SetTo,
+//	})
+type Budget struct {
+	// SoftLimit is the soft limit of bytes in use, zero means unlimited.
+	SoftLimit int64
+
+	// HardLimit is the hard limit of bytes in use, zero means unlimited.
+	// Allocations which exceed the hard limit fail with an unavailable status.
+	HardLimit int64
+
+	// OnSoftLimit is called with true when the bytes in use exceed the soft limit,
+	// and with false when they drop back below it.
+	//
+	// The callback is called in an allocating or freeing goroutine, the calls are serialized
+	// and alternate, the last call reports the current state. The callback must not block
+	// and must not allocate or free heap blocks.
+	OnSoftLimit func(exceeded bool)
+}
+
+// Stats contains heap memory counters.
+type Stats struct {
+	// InUse is the number of bytes in blocks allocated and not freed yet.
+	InUse int64
+
+	// MaxInUse is the high watermark of bytes in use.
+	MaxInUse int64
+
+	// Pooled is the number of bytes in pooled free blocks.
+	// It is an upper bound, because the pools can be cleared by the garbage collector.
+	Pooled int64
+
+	// Classes contains per size class counters, large blocks are not included.
+	Classes []ClassStats
+}
+
+// ClassStats contains memory counters of a block size class.
+type ClassStats struct {
+	Size     int   // block size
+	InUse    int64 // bytes in use
+	MaxInUse int64 // high watermark of bytes in use
+	Pooled   int64 // bytes in pooled blocks, an upper bound
+}
+
+// SetBudget sets the heap memory budget, the zero budget means unlimited.
+func (h *Heap) SetBudget(b Budget) {
+	h.budget.Store(&b)
+	h.checkSoftLimit()
+}
+
+// Stats returns the heap memory counters.
+func (h *Heap) Stats() Stats {
+	s := Stats{
+		InUse:    h.inUse.Load(),
+		MaxInUse: h.maxInUse.Load(),
+		Classes:  make([]ClassStats, 0, maxIndex-minIndex+1),
+	}
+
+	for i := minIndex; i <= maxIndex; i++ {
+		c := &h.classes[i]
+		pooled := h.pools[i].pooled.Load()
+
+		s.Pooled += pooled
+		s.Classes = append(s.Classes, ClassStats{
+			Size:     1 << i,
+			InUse:    c.inUse.Load(),
+			MaxInUse: c.maxInUse.Load(),
+			Pooled:   pooled,
+		})
+	}
+	return s
+}
+
+// internal
+
+type classCounters struct {
+	inUse    atomic.Int64
+	maxInUse atomic.Int64
+}
+
+// acquire accounts allocated bytes, returns an unavailable status if the hard limit is exceeded.
+func (h *Heap) acquire(index int, size int) status.Status {
+	n := int64(size)
+	b := h.budget.Load()
+
+	// Check hard limit
+	inUse := h.inUse.Add(n)
+	if b != nil && b.HardLimit > 0 && inUse > b.HardLimit {
+		h.inUse.Add(-n)
+		return status.Unavailablef("alloc: memory budget exceeded, in use=%d, size=%d, limit=%d",
+			inUse-n, size, b.HardLimit)
+	}
+	storeMax(&h.maxInUse, inUse)
+
+	// Update class
+	if index >= minIndex && index <= maxIndex {
+		c := &h.classes[index]
+		storeMax(&c.maxInUse, c.inUse.Add(n))
+	}
+
+	// Check soft limit
+	if b.softExceeded(inUse) != h.softExceeded.Load() {
+		h.checkSoftLimit()
+	}
+	return status.OK
+}
+
+// release accounts freed bytes.
+func (h *Heap) release(index int, size int) {
+	n := int64(size)
+	inUse := h.inUse.Add(-n)
+
+	if index >= minIndex && index <= maxIndex {
+		c := &h.classes[index]
+		c.inUse.Add(-n)
+	}
+
+	if h.softExceeded.Load() {
+		b := h.budget.Load()
+		if !b.softExceeded(inUse) {
+			h.checkSoftLimit()
+		}
+	}
+}
+
+// checkSoftLimit calls the soft limit callback when the limit is crossed.
+//
+// The calls are serialized, the method rereads the bytes in use after each call,
+// so that a concurrent crossing is not lost, and the last call reports the current state.
+func (h *Heap) checkSoftLimit() {
+	h.softMu.Lock()
+	defer h.softMu.Unlock()
+
+	for {
+		b := h.budget.Load()
+		exceeded := b.softExceeded(h.inUse.Load())
+		if exceeded == h.softExceeded.Load() {
+			return
+		}
+		h.softExceeded.Store(exceeded)
+
+		if b != nil && b.OnSoftLimit != nil {
+			b.OnSoftLimit(exceeded)
+		}
+	}
+}
+
+// softExceeded returns true if bytes in use exceed the soft limit, the budget can be nil.
+func (b *Budget) softExceeded(inUse int64) bool {
+	return b != nil && b.SoftLimit > 0 && inUse > b.SoftLimit
+}
+
+func storeMax(v *atomic.Int64, n int64) {
+	for {
+		max := v.Load()
+		if n <= max {
+			return
+		}
+		if v.CompareAndSwap(max, n) {
+			return
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package heap
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Stats
+
+func TestHeap_Stats__should_count_bytes_in_use(t *testing.T) {
+	h := New()
+	b0 := h.Alloc(1024)
+	b1 := h.Alloc(2048)
+
+	s := h.Stats()
+	assert.Equal(t, int64(3072), s.InUse)
+	assert.Equal(t, int64(3072), s.MaxInUse)
+	assert.Equal(t, int64(1024), s.Classes[0].InUse)
+	assert.Equal(t, int64(2048), s.Classes[1].InUse)
+
+	h.Free(b0)
+	h.Free(b1)
+
+	s = h.Stats()
+	assert.Equal(t, int64(0), s.InUse)
+	assert.Equal(t, int64(3072), s.MaxInUse)
+	assert.Equal(t, int64(1024), s.Classes[0].MaxInUse)
+}
+
+func TestHeap_Stats__should_count_pooled_bytes(t *testing.T) {
+	h := New()
+	if h.IsDebug() {
+		t.Skip("Blocks are not pooled in debug mode")
+	}
+
+	b := h.Alloc(1024)
+	h.Free(b)
+
+	s := h.Stats()
+	assert.Equal(t, int64(1024), s.Pooled)
+	assert.Equal(t, int64(1024), s.Classes[0].Pooled)
+}
+
+func TestHeap_Stats__should_count_large_blocks(t *testing.T) {
+	h := New()
+	b := h.Alloc(MaxBlockSize + 1)
+
+	s := h.Stats()
+	assert.Equal(t, int64(MaxBlockSize+1), s.InUse)
+
+	h.Free(b)
+	s = h.Stats()
+	assert.Equal(t, int64(0), s.InUse)
+	assert.Equal(t, int64(0), s.Pooled)
+}
+
+// Budget
+
+func TestHeap_TryAlloc__should_return_error_when_hard_limit_exceeded(t *testing.T) {
+	h := New()
+	h.SetBudget(Budget{HardLimit: 2048})
+
+	b, st := h.TryAlloc(2048)
+	require.True(t, st.OK())
+
+	_, st = h.TryAlloc(1)
+	assert.Equal(t, status.CodeUnavailable, st.Code)
+	assert.Equal(t, int64(2048), h.Stats().InUse)
+
+	h.Free(b)
+	_, st = h.TryAlloc(1)
+	assert.True(t, st.OK())
+}
+
+func TestHeap_Alloc__should_panic_when_hard_limit_exceeded(t *testing.T) {
+	h := New()
+	h.SetBudget(Budget{HardLimit: 1024})
+	h.Alloc(1024)
+
+	assert.Panics(t, func() {
+		h.Alloc(1024)
+	})
+}
+
+func TestHeap_Alloc__should_call_callback_when_soft_limit_crossed(t *testing.T) {
+	var events []bool
+
+	h := New()
+	h.SetBudget(Budget{
+		SoftLimit: 2048,
+		OnSoftLimit: func(exceeded bool) {
+			events = append(events, exceeded)
+		},
+	})
+
+	b0 := h.Alloc(2048)
+	assert.Nil(t, events)
+
+	b1 := h.Alloc(1024)
+	b2 := h.Alloc(1024)
+	assert.Equal(t, []bool{true}, events)
+
+	h.Free(b1)
+	h.Free(b2)
+	assert.Equal(t, []bool{true, false}, events)
+
+	h.Free(b0)
+	assert.Equal(t, []bool{true, false}, events)
+}
+
+func TestHeap_Alloc__should_report_current_soft_limit_state(t *testing.T) {
+	var mu sync.Mutex
+	var events []bool
+	var last atomic.Bool
+
+	h := New()
+	h.SetBudget(Budget{
+		SoftLimit: 4096,
+		OnSoftLimit: func(exceeded bool) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			events = append(events, exceeded)
+			last.Store(exceeded)
+		},
+	})
+
+	wg := sync.WaitGroup{}
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for range 1000 {
+				b := h.Alloc(1024)
+				h.Free(b)
+			}
+		}()
+	}
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for i := 1; i < len(events); i++ {
+		require.NotEqual(t, events[i-1], events[i])
+	}
+	assert.False(t, last.Load())
+	assert.Equal(t, int64(0), h.Stats().InUse)
+}
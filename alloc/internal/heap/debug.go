@@ -0,0 +1,89 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package heap
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+)
+
+// Debug enables the debug mode in the global heap and arenas.
+//
+// The debug mode is enabled by the allocdebug build tag or by the ALLOC_DEBUG env variable.
+// In the debug mode:
+//   - freed blocks are poisoned with [PoisonByte] and are never reused,
+//   - blocks record the stacks of their allocation and free,
+//   - double free panics with both stacks.
+//
+// The debug mode is slow and leaks memory to the garbage collector, use it only in tests.
+var Debug = debugTag || os.Getenv(DebugEnv) != ""
+
+const (
+	// DebugEnv is the env variable which enables the debug mode.
+	DebugEnv = "ALLOC_DEBUG"
+
+	// PoisonByte is written to freed blocks in the debug mode.
+	PoisonByte = 0xdd
+)
+
+// IsPoisoned returns true if all bytes are equal to [PoisonByte].
+func IsPoisoned(b []byte) bool {
+	if len(b) == 0 {
+		return false
+	}
+
+	for _, v := range b {
+		if v != PoisonByte {
+			return false
+		}
+	}
+	return true
+}
+
+// internal
+
+type blockDebug struct {
+	freed      bool
+	allocStack []byte
+	freeStack  []byte
+	resetStack []byte // last reset, maybe nil
+}
+
+func newBlockDebug() *blockDebug {
+	return &blockDebug{allocStack: debug.Stack()}
+}
+
+// debugFree poisons the block and records the free stack, panics on double free.
+func (b *Block) debugFree() {
+	d := b.debug
+	if d.freed {
+		panic(fmt.Sprintf("heap: double free of block\n\nallocated at:\n%s\nfreed at:\n%s\ndouble freed at:\n%s",
+			d.allocStack, d.freeStack, debug.Stack()))
+	}
+
+	d.freed = true
+	d.freeStack = debug.Stack()
+
+	buf := b.buf[:cap(b.buf)]
+	for i := range buf {
+		buf[i] = PoisonByte
+	}
+}
+
+// checkFreed panics if the block has been freed.
+func (b *Block) checkFreed() {
+	d := b.debug
+	if d == nil || !d.freed {
+		return
+	}
+
+	msg := fmt.Sprintf("heap: use of freed block\n\nallocated at:\n%s\nfreed at:\n%s",
+		d.allocStack, d.freeStack)
+	if d.resetStack != nil {
+		msg += fmt.Sprintf("\nlast reset at:\n%s", d.resetStack)
+	}
+	panic(msg)
+}
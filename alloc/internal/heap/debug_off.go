@@ -0,0 +1,10 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+//go:build !allocdebug
+// +build !allocdebug
+
+package heap
+
+const debugTag = false
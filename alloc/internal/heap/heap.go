@@ -4,50 +4,113 @@
 
 package heap
 
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/basecomplextech/baselibrary/status"
+)
+
 var Global = New()
 
 type Heap struct {
 	pools pools
+	debug bool
+
+	// budget
+	budget       atomic.Pointer[Budget]
+	softMu       sync.Mutex // serializes soft limit callbacks
+	softExceeded atomic.Bool
+
+	// stats
+	inUse    atomic.Int64
+	maxInUse atomic.Int64
+	classes  [maxIndex + 1]classCounters
 }
 
-// New returns a new heap.
+// New returns a new heap, the heap is in the debug mode when [Debug] is enabled.
 func New() *Heap {
 	return &Heap{
 		pools: newPools(),
+		debug: Debug,
+	}
+}
+
+// NewDebug returns a new heap in the debug mode, see [Debug].
+func NewDebug() *Heap {
+	return &Heap{
+		pools: newPools(),
+		debug: true,
 	}
 }
 
-// Alloc allocates a new block.
+// IsDebug returns true if the heap is in the debug mode.
+func (h *Heap) IsDebug() bool {
+	return h.debug
+}
+
+// Alloc allocates a new block, panics if the memory budget is exceeded.
 func (h *Heap) Alloc(size int) *Block {
-	i := blockPool(size)
-	if i > maxIndex {
-		return newBlock(size)
+	b, st := h.TryAlloc(size)
+	if !st.OK() {
+		panic(st.ToError())
 	}
+	return b
+}
 
+// TryAlloc allocates a new block, or returns an unavailable status if the memory budget is exceeded.
+func (h *Heap) TryAlloc(size int) (*Block, status.Status) {
+	i := blockPool(size)
 	if i < minIndex {
 		i = minIndex
 	}
 
+	// Large block
+	if i > maxIndex {
+		if st := h.acquire(i, size); !st.OK() {
+			return nil, st
+		}
+		return h.newBlock(size), status.OK
+	}
+
+	// Pooled block
+	n := 1 << i
+	if st := h.acquire(i, n); !st.OK() {
+		return nil, st
+	}
+
+	if h.debug {
+		return h.newBlock(n), status.OK
+	}
+
 	pool := h.pools[i]
-	block := pool.Get().(*Block)
-	return block
+	return pool.get(), status.OK
 }
 
 // Free frees a block.
 func (h *Heap) Free(b *Block) {
+	if h.debug {
+		b.debugFree()
+	}
+
 	cp := cap(b.buf)
+	i := blockPool(cp)
+	h.release(i, cp)
+
+	if h.debug {
+		return
+	}
 	if !isPowerOfTwo(cp) {
 		return
 	}
-
-	i := blockPool(cp)
 	if i < minIndex || i > maxIndex {
 		return
 	}
 
 	b.reset()
+
 	pool := h.pools[i]
-	pool.Put(b)
+	pool.put(b)
 }
 
 // FreeMany frees multiple blocks.
@@ -56,3 +119,13 @@ func (h *Heap) FreeMany(blocks ...*Block) {
 		h.Free(block)
 	}
 }
+
+// private
+
+func (h *Heap) newBlock(size int) *Block {
+	b := newBlock(size)
+	if h.debug {
+		b.debug = newBlockDebug()
+	}
+	return b
+}
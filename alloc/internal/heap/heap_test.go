@@ -61,3 +61,81 @@ func TestHeap_FreeMany__should_free_blocks(t *testing.T) {
 
 	h.FreeMany(blocks...)
 }
+
+// Truncate
+
+func TestBlock_Truncate__should_truncate_and_zero_block(t *testing.T) {
+	h := New()
+	b := h.Alloc(16)
+
+	p := b.Grow(8)
+	copy(p, "abcdefgh")
+	b.Truncate(4)
+
+	require.Equal(t, 4, b.Len())
+	require.Equal(t, []byte{0, 0, 0, 0}, b.buf[4:8])
+}
+
+// Debug
+
+func TestHeap_Free__should_poison_block_in_debug_mode(t *testing.T) {
+	h := NewDebug()
+	b := h.Alloc(16)
+	copy(b.Grow(8), "abcdefgh")
+
+	h.Free(b)
+	require.True(t, IsPoisoned(b.buf[:cap(b.buf)]))
+}
+
+func TestHeap_Free__should_not_reuse_blocks_in_debug_mode(t *testing.T) {
+	h := NewDebug()
+	b := h.Alloc(16)
+	h.Free(b)
+
+	b1 := h.Alloc(16)
+	require.NotSame(t, b, b1)
+}
+
+func TestHeap_Free__should_panic_on_double_free_in_debug_mode(t *testing.T) {
+	h := NewDebug()
+	b := h.Alloc(16)
+	h.Free(b)
+
+	require.Panics(t, func() {
+		h.Free(b)
+	})
+}
+
+func TestBlock_Reset__should_panic_when_freed_in_debug_mode(t *testing.T) {
+	h := NewDebug()
+	b := h.Alloc(16)
+	h.Free(b)
+
+	require.Panics(t, func() {
+		b.Reset()
+	})
+}
+
+func TestBlock_Alloc__should_panic_when_freed_in_debug_mode(t *testing.T) {
+	h := NewDebug()
+	b := h.Alloc(16)
+	h.Free(b)
+
+	require.Panics(t, func() {
+		b.Alloc(8)
+	})
+}
+
+func TestBlock_Reset__should_report_reset_stack_on_use_after_free_in_debug_mode(t *testing.T) {
+	h := NewDebug()
+	b := h.Alloc(16)
+	b.Reset()
+	h.Free(b)
+
+	defer func() {
+		e := recover()
+		require.NotNil(t, e)
+		require.Contains(t, e.(string), "last reset at:")
+	}()
+	b.Grow(8)
+}
@@ -7,6 +7,7 @@ package heap
 import (
 	"math/bits"
 	"sync"
+	"sync/atomic"
 )
 
 const (
@@ -14,7 +15,7 @@ const (
 	maxIndex = 27 // 128MB
 )
 
-type pools [maxIndex + 1]*sync.Pool
+type pools [maxIndex + 1]*pool
 
 func newPools() (pools pools) {
 	for j := minIndex; j <= maxIndex; j++ {
@@ -25,12 +26,35 @@ func newPools() (pools pools) {
 	return
 }
 
-func newPool(size int) *sync.Pool {
-	return &sync.Pool{
-		New: func() any {
-			return newBlock(size)
-		},
+// pool is a pool of free blocks of a size class.
+type pool struct {
+	size   int
+	pool   sync.Pool
+	pooled atomic.Int64 // bytes in free blocks, an upper bound
+}
+
+func newPool(size int) *pool {
+	return &pool{size: size}
+}
+
+// get returns a free block, or allocates a new one.
+func (p *pool) get() *Block {
+	v := p.pool.Get()
+	if v == nil {
+		return newBlock(p.size)
 	}
+
+	p.pooled.Add(-int64(p.size))
+	return v.(*Block)
+}
+
+// put adds a free block to the pool.
+//
+// The pooled bytes are an upper bound, because the garbage collector
+// can release free blocks without notifying the pool.
+func (p *pool) put(b *Block) {
+	p.pooled.Add(int64(p.size))
+	p.pool.Put(b)
 }
 
 func blockPool(size int) int {
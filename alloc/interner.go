@@ -0,0 +1,162 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package alloc
+
+import "sync"
+
+// Interner deduplicates strings, it returns one canonical arena-backed string
+// per distinct byte sequence.
+//
+// The interned strings are valid only until the arena is reset or freed.
+type Interner interface {
+	// Len returns the number of interned strings.
+	Len() int
+
+	// Lookup returns an interned string, or false, does not allocate.
+	Lookup(b []byte) (string, bool)
+
+	// Bytes returns an interned string, or copies bytes into the arena and interns them.
+	// The method does not allocate when the string is already interned.
+	Bytes(b []byte) string
+
+	// String returns an interned string, or copies a string into the arena and interns it.
+	String(s string) string
+}
+
+// NewInterner returns a new non-thread-safe interner which allocates strings in the arena.
+func NewInterner(a Arena) Interner {
+	return newInterner(a, nil)
+}
+
+// NewInternerParent returns a new non-thread-safe interner which looks up strings in a parent
+// interner first, and allocates only missing strings in the arena.
+//
+// Usually, the parent is a long-lived shared interner with common symbols,
+// and the child is a short-lived per-request interner.
+//
+// Usage:
+//
+//	symbols := alloc.NewSharedInterner(alloc.NewArena())
+//	symbols.String("id")
+//	symbols.String("name")
+//
+//	arena := alloc.AcquireArena()
+//	defer arena.Free()
+//
+//	interner := alloc.NewInternerParent(arena, symbols)
+//	interner.Bytes([]byte("id")) // returns the shared string
+func NewInternerParent(a Arena, parent Interner) Interner {
+	return newInterner(a, parent)
+}
+
+// NewSharedInterner returns a new thread-safe interner which can be shared by multiple goroutines.
+// The arena must be used only by the interner.
+func NewSharedInterner(a Arena) Interner {
+	return newSharedInterner(a)
+}
+
+// internal
+
+var _ Interner = (*interner)(nil)
+
+type interner struct {
+	arena   Arena
+	parent  Interner // optional
+	strings Map[string, string]
+}
+
+func newInterner(a Arena, parent Interner) *interner {
+	return &interner{
+		arena:   a,
+		parent:  parent,
+		strings: NewMap[string, string](a),
+	}
+}
+
+// Len returns the number of interned strings.
+func (i *interner) Len() int {
+	return i.strings.Len()
+}
+
+// Lookup returns an interned string, or false, does not allocate.
+func (i *interner) Lookup(b []byte) (string, bool) {
+	if i.parent != nil {
+		if s, ok := i.parent.Lookup(b); ok {
+			return s, true
+		}
+	}
+
+	return i.strings.Get(unsafeString(b))
+}
+
+// Bytes returns an interned string, or copies bytes into the arena and interns them.
+// The method does not allocate when the string is already interned.
+func (i *interner) Bytes(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+
+	if s, ok := i.Lookup(b); ok {
+		return s
+	}
+
+	s := StringBytes(i.arena, b)
+	i.strings.Set(s, s)
+	return s
+}
+
+// String returns an interned string, or copies a string into the arena and interns it.
+func (i *interner) String(s string) string {
+	b := unsafeStringBytes(s)
+	return i.Bytes(b)
+}
+
+// shared
+
+var _ Interner = (*sharedInterner)(nil)
+
+type sharedInterner struct {
+	mu sync.RWMutex
+	*interner
+}
+
+func newSharedInterner(a Arena) *sharedInterner {
+	return &sharedInterner{interner: newInterner(a, nil)}
+}
+
+// Len returns the number of interned strings.
+func (i *sharedInterner) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.interner.Len()
+}
+
+// Lookup returns an interned string, or false, does not allocate.
+func (i *sharedInterner) Lookup(b []byte) (string, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.interner.Lookup(b)
+}
+
+// Bytes returns an interned string, or copies bytes into the arena and interns them.
+// The method does not allocate when the string is already interned.
+func (i *sharedInterner) Bytes(b []byte) string {
+	if s, ok := i.Lookup(b); ok {
+		return s
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.interner.Bytes(b)
+}
+
+// String returns an interned string, or copies a string into the arena and interns it.
+func (i *sharedInterner) String(s string) string {
+	b := unsafeStringBytes(s)
+	return i.Bytes(b)
+}
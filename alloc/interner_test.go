@@ -0,0 +1,92 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package alloc
+
+import (
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/basecomplextech/baselibrary/alloc/internal/arena"
+	"github.com/stretchr/testify/assert"
+)
+
+// Bytes
+
+func TestInterner_Bytes__should_return_canonical_string(t *testing.T) {
+	a := arena.Test()
+	i := NewInterner(a)
+
+	s0 := i.Bytes([]byte("hello"))
+	s1 := i.Bytes([]byte("hello"))
+	s2 := i.String("hello")
+
+	assert.Equal(t, "hello", s0)
+	assert.Equal(t, unsafe.StringData(s0), unsafe.StringData(s1))
+	assert.Equal(t, unsafe.StringData(s0), unsafe.StringData(s2))
+	assert.Equal(t, 1, i.Len())
+}
+
+func TestInterner_Bytes__should_copy_bytes_into_arena(t *testing.T) {
+	a := arena.Test()
+	i := NewInterner(a)
+
+	b := []byte("hello")
+	s := i.Bytes(b)
+	b[0] = 'x'
+
+	assert.Equal(t, "hello", s)
+}
+
+func TestInterner_Bytes__should_not_allocate_when_interned(t *testing.T) {
+	a := arena.Test()
+	i := NewInterner(a)
+	b := []byte("hello")
+	i.Bytes(b)
+
+	n := testing.AllocsPerRun(100, func() {
+		i.Bytes(b)
+	})
+	assert.Equal(t, float64(0), n)
+}
+
+// Parent
+
+func TestInterner_Bytes__should_return_parent_string(t *testing.T) {
+	parent := NewSharedInterner(arena.Test())
+	s0 := parent.String("hello")
+
+	a := arena.Test()
+	i := NewInternerParent(a, parent)
+
+	s1 := i.Bytes([]byte("hello"))
+	assert.Equal(t, unsafe.StringData(s0), unsafe.StringData(s1))
+	assert.Equal(t, 0, i.Len())
+
+	i.String("world")
+	assert.Equal(t, 1, i.Len())
+	assert.Equal(t, 1, parent.Len())
+}
+
+// Shared
+
+func TestSharedInterner__should_be_thread_safe(t *testing.T) {
+	i := NewSharedInterner(arena.Test())
+	keys := []string{"a", "b", "c", "d"}
+
+	var wg sync.WaitGroup
+	for n := 0; n < 8; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				i.String(keys[j%len(keys)])
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, len(keys), i.Len())
+}
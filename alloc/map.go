@@ -0,0 +1,287 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package alloc
+
+import (
+	"github.com/basecomplextech/baselibrary/hashing"
+)
+
+// Map is an open-addressing hash map which allocates its entries in an arena.
+//
+// The map is not thread-safe. The map memory is released all at once when
+// the arena is reset or freed, the map must not be used after that.
+//
+// The entries are invisible to the garbage collector, so keys and values must
+// reference only the arena memory, see [NewStringMap] and [Arena.Pin].
+type Map[K comparable, V any] interface {
+	// Len returns the number of keys.
+	Len() int
+
+	// Clear deletes all items, retains the allocated capacity.
+	Clear()
+
+	// Contains returns true if a key exists.
+	Contains(key K) bool
+
+	// Get returns a key value, or false.
+	Get(key K) (V, bool)
+
+	// Set sets a value for a key.
+	Set(key K, value V)
+
+	// Delete deletes a key value, and returns the previous value.
+	Delete(key K) (V, bool)
+
+	// Range iterates over all key-value pairs.
+	// The iteration stops if the function returns false.
+	// The map must not be modified during the iteration.
+	Range(fn func(K, V) bool)
+}
+
+// NewMap returns a new map which allocates entries in the arena,
+// panics if the key type is not supported by [hashing.NewHasher].
+func NewMap[K comparable, V any](a Arena) Map[K, V] {
+	hasher := hashing.NewHasher[K]()
+	return newMap[K, V](a, hasher, 0)
+}
+
+// NewMapSize returns a new map with a preallocated capacity.
+func NewMapSize[K comparable, V any](a Arena, size int) Map[K, V] {
+	hasher := hashing.NewHasher[K]()
+	return newMap[K, V](a, hasher, size)
+}
+
+// NewMapHasher returns a new map which uses a custom hasher.
+func NewMapHasher[K comparable, V any](a Arena, hasher hashing.Hasher[K]) Map[K, V] {
+	return newMap[K, V](a, hasher, 0)
+}
+
+// NewStringMap returns a new map which also copies string keys into the arena on insert.
+func NewStringMap[V any](a Arena) Map[string, V] {
+	hasher := hashing.NewHasher[string]()
+	m := newMap[string, V](a, hasher, 0)
+	m.copyKeys = true
+	return m
+}
+
+// internal
+
+const (
+	mapMinCap  = 8
+	mapLoadNum = 3 // load factor numerator
+	mapLoadDen = 4 // load factor denominator
+)
+
+var _ Map[int, int] = (*arenaMap[int, int])(nil)
+
+type arenaMap[K comparable, V any] struct {
+	arena    Arena
+	hasher   hashing.Hasher[K]
+	copyKeys bool // copy string keys into the arena
+
+	len     int
+	entries []mapEntry[K, V] // allocated in the arena, len is power of two
+}
+
+type mapEntry[K comparable, V any] struct {
+	used  bool
+	hash  uint32
+	key   K
+	value V
+}
+
+func newMap[K comparable, V any](a Arena, hasher hashing.Hasher[K], size int) *arenaMap[K, V] {
+	m := &arenaMap[K, V]{
+		arena:  a,
+		hasher: hasher,
+	}
+	if size > 0 {
+		m.grow(size)
+	}
+	return m
+}
+
+// Len returns the number of keys.
+func (m *arenaMap[K, V]) Len() int {
+	return m.len
+}
+
+// Clear deletes all items, retains the allocated capacity.
+func (m *arenaMap[K, V]) Clear() {
+	clear(m.entries)
+	m.len = 0
+}
+
+// Contains returns true if a key exists.
+func (m *arenaMap[K, V]) Contains(key K) bool {
+	_, ok := m.find(key, m.hash(key))
+	return ok
+}
+
+// Get returns a key value, or false.
+func (m *arenaMap[K, V]) Get(key K) (v V, ok bool) {
+	i, ok := m.find(key, m.hash(key))
+	if !ok {
+		return v, false
+	}
+	return m.entries[i].value, true
+}
+
+// Set sets a value for a key.
+func (m *arenaMap[K, V]) Set(key K, value V) {
+	h := m.hash(key)
+
+	// Replace existing value
+	i, ok := m.find(key, h)
+	if ok {
+		m.entries[i].value = value
+		return
+	}
+
+	// Grow if required
+	if (m.len+1)*mapLoadDen > len(m.entries)*mapLoadNum {
+		m.grow(m.len + 1)
+	}
+
+	// Copy key
+	if m.copyKeys {
+		key = m.copyKey(key)
+	}
+
+	// Insert new entry
+	m.insert(mapEntry[K, V]{
+		used:  true,
+		hash:  h,
+		key:   key,
+		value: value,
+	})
+	m.len++
+}
+
+// Delete deletes a key value, and returns the previous value.
+func (m *arenaMap[K, V]) Delete(key K) (v V, ok bool) {
+	i, ok := m.find(key, m.hash(key))
+	if !ok {
+		return v, false
+	}
+
+	v = m.entries[i].value
+	m.remove(i)
+	m.len--
+	return v, true
+}
+
+// Range iterates over all key-value pairs.
+// The iteration stops if the function returns false.
+// The map must not be modified during the iteration.
+func (m *arenaMap[K, V]) Range(fn func(K, V) bool) {
+	for i := range m.entries {
+		e := &m.entries[i]
+		if !e.used {
+			continue
+		}
+
+		if !fn(e.key, e.value) {
+			return
+		}
+	}
+}
+
+// private
+
+func (m *arenaMap[K, V]) hash(key K) uint32 {
+	// Mix bits, because primitive hashers return values as is (murmur3 finalizer)
+	h := m.hasher.Hash32(key)
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
+
+// find returns an entry index by a key, uses linear probing.
+func (m *arenaMap[K, V]) find(key K, h uint32) (int, bool) {
+	if m.len == 0 {
+		return 0, false
+	}
+
+	mask := len(m.entries) - 1
+	for i := int(h) & mask; ; i = (i + 1) & mask {
+		e := &m.entries[i]
+		switch {
+		case !e.used:
+			return 0, false
+		case e.hash == h && e.key == key:
+			return i, true
+		}
+	}
+}
+
+// insert inserts a new entry, the map must have free space.
+func (m *arenaMap[K, V]) insert(entry mapEntry[K, V]) {
+	mask := len(m.entries) - 1
+	for i := int(entry.hash) & mask; ; i = (i + 1) & mask {
+		if !m.entries[i].used {
+			m.entries[i] = entry
+			return
+		}
+	}
+}
+
+// remove removes an entry and shifts the next entries backward, so no tombstones are needed.
+func (m *arenaMap[K, V]) remove(i int) {
+	mask := len(m.entries) - 1
+
+	for j := (i + 1) & mask; ; j = (j + 1) & mask {
+		e := &m.entries[j]
+		if !e.used {
+			break
+		}
+
+		// Skip entry if its home slot is cyclically in (i, j]
+		home := int(e.hash) & mask
+		if (j > i && i < home && home <= j) || (j < i && (i < home || home <= j)) {
+			continue
+		}
+
+		m.entries[i] = *e
+		i = j
+	}
+
+	var zero mapEntry[K, V]
+	m.entries[i] = zero
+}
+
+// grow allocates a larger entry table in the arena and reinserts entries,
+// the previous table is released only when the arena is reset.
+func (m *arenaMap[K, V]) grow(size int) {
+	n := mapMinCap
+	for n*mapLoadNum < size*mapLoadDen {
+		n *= 2
+	}
+	if n <= len(m.entries) {
+		n = len(m.entries) * 2
+	}
+
+	prev := m.entries
+	m.entries = allocSlice[[]mapEntry[K, V]](m.arena, n, n)
+
+	for i := range prev {
+		if prev[i].used {
+			m.insert(prev[i])
+		}
+	}
+}
+
+func (m *arenaMap[K, V]) copyKey(key K) K {
+	s, ok := any(key).(string)
+	if !ok {
+		return key
+	}
+
+	s = String(m.arena, s)
+	return any(s).(K)
+}
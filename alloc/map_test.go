@@ -0,0 +1,140 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package alloc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/alloc/internal/arena"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Get
+
+func TestMap_Get__should_return_value(t *testing.T) {
+	a := arena.Test()
+	m := NewMap[int, int](a)
+
+	for i := 0; i < 1000; i++ {
+		m.Set(i, i*10)
+	}
+
+	for i := 0; i < 1000; i++ {
+		v, ok := m.Get(i)
+		require.True(t, ok)
+		require.Equal(t, i*10, v)
+	}
+
+	_, ok := m.Get(1000)
+	assert.False(t, ok)
+	assert.Equal(t, 1000, m.Len())
+}
+
+// Set
+
+func TestMap_Set__should_replace_value(t *testing.T) {
+	a := arena.Test()
+	m := NewMap[int, string](a)
+
+	m.Set(1, "a")
+	m.Set(1, "b")
+
+	v, ok := m.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, "b", v)
+	assert.Equal(t, 1, m.Len())
+}
+
+func TestMap_Set__should_allocate_entries_in_arena(t *testing.T) {
+	a := arena.Test()
+	m := NewMapSize[int, int](a, 100)
+
+	assert.True(t, a.Len() > 0)
+	n := a.Len()
+
+	for i := 0; i < 100; i++ {
+		m.Set(i, i)
+	}
+	assert.Equal(t, n, a.Len())
+}
+
+// Delete
+
+func TestMap_Delete__should_delete_value(t *testing.T) {
+	a := arena.Test()
+	m := NewMap[int, int](a)
+
+	for i := 0; i < 1000; i++ {
+		m.Set(i, i)
+	}
+
+	for i := 0; i < 1000; i += 2 {
+		v, ok := m.Delete(i)
+		require.True(t, ok)
+		require.Equal(t, i, v)
+	}
+
+	for i := 0; i < 1000; i++ {
+		_, ok := m.Get(i)
+		require.Equal(t, i%2 == 1, ok, i)
+	}
+
+	_, ok := m.Delete(0)
+	assert.False(t, ok)
+	assert.Equal(t, 500, m.Len())
+}
+
+// Range
+
+func TestMap_Range__should_iterate_over_items(t *testing.T) {
+	a := arena.Test()
+	m := NewMap[string, int](a)
+
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+
+	items := map[string]int{}
+	m.Range(func(k string, v int) bool {
+		items[k] = v
+		return true
+	})
+
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3}, items)
+}
+
+// Clear
+
+func TestMap_Clear__should_delete_all_items(t *testing.T) {
+	a := arena.Test()
+	m := NewMap[int, int](a)
+	m.Set(1, 1)
+	m.Set(2, 2)
+
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+	assert.False(t, m.Contains(1))
+}
+
+// StringMap
+
+func TestStringMap__should_copy_keys_into_arena(t *testing.T) {
+	a := arena.Test()
+	m := NewStringMap[int](a)
+
+	key := []byte("hello")
+	m.Set(string(key), 1)
+	n := a.Len()
+
+	key[0] = 'x'
+	v, ok := m.Get("hello")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	m.Set(fmt.Sprint("hello"), 2)
+	assert.Equal(t, n, a.Len())
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package alloc
+
+import (
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// Reader is a buffer reader which copies strings and bytes into an arena.
+//
+// The copied strings and bytes are valid until the arena is reset or freed,
+// they do not reference the underlying buffer.
+type Reader struct {
+	buffer.Reader
+	arena Arena
+}
+
+// NewReader returns a new reader of a byte slice which copies data into the arena.
+func NewReader(a Arena, b []byte) *Reader {
+	r := &Reader{arena: a}
+	r.Reset(b)
+	return r
+}
+
+// ReadString reads the next n bytes and copies them into a new arena string.
+func (r *Reader) ReadString(n int) (string, status.Status) {
+	b, st := r.ReadBytes(n)
+	if !st.OK() {
+		return "", st
+	}
+	return StringBytes(r.arena, b), status.OK
+}
+
+// ReadBytesCopy reads the next n bytes and copies them into a new arena slice.
+func (r *Reader) ReadBytesCopy(n int) ([]byte, status.Status) {
+	b, st := r.ReadBytes(n)
+	if !st.OK() {
+		return nil, st
+	}
+	return CopyBytes(r.arena, b), status.OK
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package alloc
+
+import (
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/alloc/internal/arena"
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader_ReadString__should_copy_string_into_arena(t *testing.T) {
+	a := arena.Test()
+	b := []byte("hello, world")
+	r := NewReader(a, b)
+
+	s, st := r.ReadString(5)
+	require.True(t, st.OK())
+	assert.Equal(t, "hello", s)
+
+	b[0] = 'j'
+	assert.Equal(t, "hello", s)
+	assert.Equal(t, 5, r.Pos())
+}
+
+func TestReader_ReadBytesCopy__should_copy_bytes_into_arena(t *testing.T) {
+	a := arena.Test()
+	b := []byte("hello, world")
+	r := NewReader(a, b)
+
+	_, st := r.ReadBytes(7)
+	require.True(t, st.OK())
+
+	p, st := r.ReadBytesCopy(5)
+	require.True(t, st.OK())
+	assert.Equal(t, []byte("world"), p)
+
+	b[7] = 'j'
+	assert.Equal(t, []byte("world"), p)
+}
+
+func TestReader_ReadString__should_return_parse_error_on_short_input(t *testing.T) {
+	a := arena.Test()
+	r := NewReader(a, []byte("hello"))
+
+	_, st := r.ReadString(6)
+	assert.Equal(t, status.CodeParseError, st.Code)
+}
@@ -114,3 +114,7 @@ func unsafeString(b []byte) string {
 	}
 	return *(*string)(unsafe.Pointer(&b))
 }
+
+func unsafeStringBytes(s string) []byte {
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
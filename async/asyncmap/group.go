@@ -0,0 +1,168 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package asyncmap
+
+import (
+	"time"
+
+	"github.com/basecomplextech/baselibrary/async"
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// Group coalesces concurrent calls with the same key into one shared routine.
+//
+// A caller which cancels its context detaches from the call, the shared routine is stopped
+// only when all its callers are gone. Successful results can be cached for a TTL.
+//
+// The group uses the sharded map shards, each guarded by a separate mutex. It is placed
+// in asyncmap instead of async, because asyncmap imports async, and the sharded map
+// cannot be used from async without an import cycle.
+//
+// Usage:
+//
+//	g := asyncmap.NewGroupTTL[string, *User](time.Second)
+//
+//	user, st := g.Do(ctx, id, func(ctx async.Context) (*User, status.Status) {
+//		return loadUser(ctx, id)
+//	})
+type Group[K comparable, T any] interface {
+	// Do executes a function once for concurrent calls with the same key, awaits the result.
+	// The method returns a cached result if present, or a context status if cancelled.
+	Do(ctx async.Context, key K, fn async.Func[T]) (T, status.Status)
+
+	// Forget deletes a key call or a cached result, the next call executes the function again.
+	// The running call is not stopped, its callers receive its result.
+	Forget(key K)
+}
+
+// NewGroup returns a new group without a result cache.
+func NewGroup[K comparable, T any]() Group[K, T] {
+	return newGroup[K, T](0)
+}
+
+// NewGroupTTL returns a new group which caches successful results for a TTL.
+func NewGroupTTL[K comparable, T any](ttl time.Duration) Group[K, T] {
+	return newGroup[K, T](ttl)
+}
+
+// internal
+
+var _ Group[int, int] = (*group[int, int])(nil)
+
+type group[K comparable, T any] struct {
+	ttl   time.Duration
+	calls *shardedMap[K, *groupCall[T]]
+}
+
+type groupCall[T any] struct {
+	routine async.Routine[T]
+	waiters int // guarded by shard mutex
+}
+
+func newGroup[K comparable, T any](ttl time.Duration) *group[K, T] {
+	return &group[K, T]{
+		ttl:   ttl,
+		calls: newShardedMap[K, *groupCall[T]](),
+	}
+}
+
+// Do executes a function once for concurrent calls with the same key, awaits the result.
+// The method returns a cached result if present, or a context status if cancelled.
+func (g *group[K, T]) Do(ctx async.Context, key K, fn async.Func[T]) (T, status.Status) {
+	shard := g.calls.shard(key)
+	c := g.join(shard, key, fn)
+
+	// Return a cached or completed result even if the context is cancelled,
+	// select chooses randomly between ready channels.
+	select {
+	case <-c.routine.Wait():
+	case <-ctx.Wait():
+	}
+	if c.routine.Done() {
+		shard.mu.Lock()
+		c.waiters--
+		shard.mu.Unlock()
+		return c.routine.Result()
+	}
+
+	// Detach, stop routine if last waiter
+	shard.mu.Lock()
+	c.waiters--
+	last := c.waiters == 0 && !c.routine.Done()
+	if last {
+		g.deleteCall(shard, key, c)
+	}
+	shard.mu.Unlock()
+
+	if last {
+		c.routine.Stop()
+	}
+
+	var zero T
+	return zero, ctx.Status()
+}
+
+// Forget deletes a key call or a cached result, the next call executes the function again.
+// The running call is not stopped, its callers receive its result.
+func (g *group[K, T]) Forget(key K) {
+	g.calls.Delete(key)
+}
+
+// private
+
+// join returns an existing call and increments its waiters, or starts a new call.
+func (g *group[K, T]) join(shard *shardedMapShard[K, *groupCall[T]], key K,
+	fn async.Func[T]) *groupCall[T] {
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	c, ok := shard._get(key)
+	if ok {
+		c.waiters++
+		return c
+	}
+
+	c = &groupCall[T]{
+		routine: async.NewRoutine(fn),
+		waiters: 1,
+	}
+	shard._set(key, c)
+
+	c.routine.OnStop(func(r async.Routine[T]) {
+		g.complete(key, c)
+	})
+	c.routine.Start()
+	return c
+}
+
+// complete caches a successful result, or deletes the call.
+func (g *group[K, T]) complete(key K, c *groupCall[T]) {
+	shard := g.calls.shard(key)
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	if g.ttl <= 0 || !c.routine.Status().OK() {
+		g.deleteCall(shard, key, c)
+		return
+	}
+
+	time.AfterFunc(g.ttl, func() {
+		shard.mu.Lock()
+		defer shard.mu.Unlock()
+
+		g.deleteCall(shard, key, c)
+	})
+}
+
+// deleteCall deletes a key call if it is the current one, must be called under the shard lock.
+func (g *group[K, T]) deleteCall(shard *shardedMapShard[K, *groupCall[T]], key K, c *groupCall[T]) {
+	c1, ok := shard._get(key)
+	if !ok || c1 != c {
+		return
+	}
+	shard._delete(key)
+}
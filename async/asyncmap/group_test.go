@@ -0,0 +1,221 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package asyncmap
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/async"
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGroupWaiters[K comparable, T any](t *testing.T, g *group[K, T], key K, n int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		shard := g.calls.shard(key)
+		shard.mu.Lock()
+		defer shard.mu.Unlock()
+
+		c, ok := shard._get(key)
+		return ok && c.waiters == n
+	}, time.Second, time.Millisecond)
+}
+
+// Do
+
+func TestGroup_Do__should_share_call_between_callers(t *testing.T) {
+	g := newGroup[int, int](0)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fn := func(ctx async.Context) (int, status.Status) {
+		calls.Add(1)
+		<-release
+		return 123, status.OK
+	}
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, st := g.Do(async.NoContext(), 1, fn)
+			require.True(t, st.OK())
+			results[i] = v
+		}()
+	}
+
+	testGroupWaiters(t, g, 1, 10)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for _, v := range results {
+		assert.Equal(t, 123, v)
+	}
+	require.Eventually(t, func() bool {
+		return g.calls.Len() == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestGroup_Do__should_detach_cancelled_caller(t *testing.T) {
+	g := newGroup[int, int](0)
+
+	release := make(chan struct{})
+	fn := func(ctx async.Context) (int, status.Status) {
+		select {
+		case <-release:
+			return 123, status.OK
+		case <-ctx.Wait():
+			return 0, ctx.Status()
+		}
+	}
+
+	ctx := async.NewContext()
+	defer ctx.Free()
+
+	cancelled := make(chan status.Status, 1)
+	go func() {
+		_, st := g.Do(ctx, 1, fn)
+		cancelled <- st
+	}()
+	testGroupWaiters(t, g, 1, 1)
+
+	other := make(chan status.Status, 1)
+	go func() {
+		_, st := g.Do(async.NoContext(), 1, fn)
+		other <- st
+	}()
+	testGroupWaiters(t, g, 1, 2)
+
+	ctx.Cancel()
+	assert.Equal(t, status.Cancelled, <-cancelled)
+
+	close(release)
+	assert.True(t, (<-other).OK())
+}
+
+func TestGroup_Do__should_stop_call_when_all_callers_cancelled(t *testing.T) {
+	g := newGroup[int, int](0)
+
+	stopped := make(chan struct{})
+	fn := func(ctx async.Context) (int, status.Status) {
+		<-ctx.Wait()
+		close(stopped)
+		return 0, ctx.Status()
+	}
+
+	ctx := async.NewContext()
+	defer ctx.Free()
+
+	done := make(chan status.Status, 1)
+	go func() {
+		_, st := g.Do(ctx, 1, fn)
+		done <- st
+	}()
+	testGroupWaiters(t, g, 1, 1)
+
+	ctx.Cancel()
+	assert.Equal(t, status.Cancelled, <-done)
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("call not stopped")
+	}
+	assert.False(t, g.calls.Contains(1))
+}
+
+func TestGroup_Do__should_cache_result_for_ttl(t *testing.T) {
+	g := newGroup[int, int](50 * time.Millisecond)
+
+	var calls atomic.Int32
+	fn := func(ctx async.Context) (int, status.Status) {
+		return int(calls.Add(1)), status.OK
+	}
+
+	v, st := g.Do(async.NoContext(), 1, fn)
+	require.True(t, st.OK())
+	assert.Equal(t, 1, v)
+
+	v, st = g.Do(async.NoContext(), 1, fn)
+	require.True(t, st.OK())
+	assert.Equal(t, 1, v)
+
+	require.Eventually(t, func() bool {
+		return !g.calls.Contains(1)
+	}, time.Second, time.Millisecond)
+
+	v, st = g.Do(async.NoContext(), 1, fn)
+	require.True(t, st.OK())
+	assert.Equal(t, 2, v)
+}
+
+func TestGroup_Do__should_return_cached_result_when_context_cancelled(t *testing.T) {
+	g := newGroup[int, int](time.Minute)
+	fn := func(ctx async.Context) (int, status.Status) {
+		return 1, status.OK
+	}
+
+	_, st := g.Do(async.NoContext(), 1, fn)
+	require.True(t, st.OK())
+
+	ctx := async.NewContext()
+	defer ctx.Free()
+	ctx.Cancel()
+
+	for i := 0; i < 100; i++ {
+		v, st := g.Do(ctx, 1, fn)
+		require.True(t, st.OK())
+		assert.Equal(t, 1, v)
+	}
+}
+
+func TestGroup_Do__should_not_cache_error(t *testing.T) {
+	g := newGroup[int, int](time.Minute)
+
+	var calls atomic.Int32
+	fn := func(ctx async.Context) (int, status.Status) {
+		calls.Add(1)
+		return 0, status.Error("test")
+	}
+
+	_, st := g.Do(async.NoContext(), 1, fn)
+	assert.Equal(t, status.Error("test"), st)
+
+	require.Eventually(t, func() bool {
+		return !g.calls.Contains(1)
+	}, time.Second, time.Millisecond)
+
+	_, st = g.Do(async.NoContext(), 1, fn)
+	assert.Equal(t, status.Error("test"), st)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+// Forget
+
+func TestGroup_Forget__should_delete_cached_result(t *testing.T) {
+	g := newGroup[int, int](time.Minute)
+
+	var calls atomic.Int32
+	fn := func(ctx async.Context) (int, status.Status) {
+		return int(calls.Add(1)), status.OK
+	}
+
+	v, _ := g.Do(async.NoContext(), 1, fn)
+	assert.Equal(t, 1, v)
+
+	g.Forget(1)
+
+	v, _ = g.Do(async.NoContext(), 1, fn)
+	assert.Equal(t, 2, v)
+}
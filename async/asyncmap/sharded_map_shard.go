@@ -60,16 +60,7 @@ func (s *shardedMapShard[K, V]) get(key K) (v V, _ bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if m, ok := s.entry.Unwrap(); ok {
-		if m.key == key {
-			return m.value, true
-		}
-	}
-	if more, ok := s.more.Unwrap(); ok {
-		v, ok := more[key]
-		return v, ok
-	}
-	return v, false
+	return s._get(key)
 }
 
 func (s *shardedMapShard[K, V]) getOrSet(key K, value V) (v V, ok bool) {
@@ -106,20 +97,7 @@ func (s *shardedMapShard[K, V]) delete(key K) (v V, _ bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if m, ok := s.entry.Unwrap(); ok {
-		if m.key == key {
-			s.entry.Clear()
-			return m.value, true
-		}
-	}
-	if more, ok := s.more.Unwrap(); ok {
-		v, ok = more[key]
-		if ok {
-			delete(more, key)
-		}
-		return v, ok
-	}
-	return v, false
+	return s._delete(key)
 }
 
 func (s *shardedMapShard[K, V]) set(key K, value V) {
@@ -196,6 +174,36 @@ func (s *shardedMapShard[K, V]) _contains(key K) bool {
 	return false
 }
 
+func (s *shardedMapShard[K, V]) _get(key K) (v V, _ bool) {
+	if m, ok := s.entry.Unwrap(); ok {
+		if m.key == key {
+			return m.value, true
+		}
+	}
+	if more, ok := s.more.Unwrap(); ok {
+		v, ok := more[key]
+		return v, ok
+	}
+	return v, false
+}
+
+func (s *shardedMapShard[K, V]) _delete(key K) (v V, _ bool) {
+	if m, ok := s.entry.Unwrap(); ok {
+		if m.key == key {
+			s.entry.Clear()
+			return m.value, true
+		}
+	}
+	if more, ok := s.more.Unwrap(); ok {
+		v, ok = more[key]
+		if ok {
+			delete(more, key)
+		}
+		return v, ok
+	}
+	return v, false
+}
+
 func (s *shardedMapShard[K, V]) _set(key K, value V) {
 	if !s.entry.Valid {
 		e := shardedMapEntry[K, V]{key: key, value: value}
@@ -0,0 +1,43 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package asyncrc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/async"
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThen__should_retain_and_release_refcounted_futures(t *testing.T) {
+	p0 := NewPromise[int]()
+	p1 := NewPromise[int]()
+
+	f := async.Then[int, int](p0, func(v int) async.Future[int] {
+		p1.Resolve(v + 1)
+		return p1
+	})
+	assert.Equal(t, int64(2), p0.Refcount())
+	p1.Retain() // keep for assertions
+
+	go p0.Resolve(1)
+	select {
+	case <-f.Wait():
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+
+	v, st := f.Result()
+	require.Equal(t, status.OK, st)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, int64(1), p0.Refcount())
+	assert.Equal(t, int64(1), p1.Refcount())
+
+	p0.Release()
+	p1.Release()
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import "time"
+
+// Clock provides the current time and timers, can be replaced in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// NewTimer returns a new timer which sends the current time after the duration.
+	NewTimer(d time.Duration) ClockTimer
+}
+
+// ClockTimer is a timer returned by a clock.
+type ClockTimer interface {
+	// C returns a channel which receives the time when the timer fires.
+	C() <-chan time.Time
+
+	// Stop stops the timer, returns false if the timer has already fired or been stopped.
+	Stop() bool
+}
+
+// SystemClock returns a clock which uses the system time.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// internal
+
+var _ Clock = systemClock{}
+
+type systemClock struct{}
+
+// Now returns the current time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTimer returns a new timer which sends the current time after the duration.
+func (systemClock) NewTimer(d time.Duration) ClockTimer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+// timer
+
+var _ ClockTimer = systemTimer{}
+
+type systemTimer struct {
+	t *time.Timer
+}
+
+// C returns a channel which receives the time when the timer fires.
+func (t systemTimer) C() <-chan time.Time {
+	return t.t.C
+}
+
+// Stop stops the timer, returns false if the timer has already fired or been stopped.
+func (t systemTimer) Stop() bool {
+	return t.t.Stop()
+}
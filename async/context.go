@@ -68,9 +68,40 @@ func NextDeadlineContext(parent Context, deadline time.Time) Context {
 	return context.NextDeadline(parent, deadline)
 }
 
+// Values
+
+// WithValue returns a context with a value, the context shares cancellation with the parent,
+// and frees the parent when freed, so it can be used in place of the parent. The key must be
+// comparable, and should be of an unexported type.
+//
+// Example:
+//
+//	type requestIDKey struct{}
+//
+//	ctx = async.WithValue(ctx, requestIDKey{}, "123")
+//	id, ok := async.Value[string](ctx, requestIDKey{})
+func WithValue(parent Context, key any, value any) Context {
+	return context.WithValue(parent, key, value)
+}
+
+// Value returns a typed context value for a key, or false if absent or of another type.
+func Value[T any](ctx Context, key any) (T, bool) {
+	v, ok := ctx.Value(key).(T)
+	return v, ok
+}
+
 // Standard
 
-// StdContext returns a standard library context from an async one.
+// StdContext returns a standard library context from an async one,
+// the standard context has the async context deadline and values.
 func StdContext(ctx Context) context_.Context {
 	return context.Std(ctx)
 }
+
+// FromStdContext returns an async context from a standard library one, the context must be freed.
+//
+// The context is cancelled when the standard context is done, with a timeout status
+// if its deadline is exceeded. The context inherits the standard context deadline and values.
+func FromStdContext(ctx context_.Context) CancelContext {
+	return context.FromStd(ctx)
+}
@@ -0,0 +1,290 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"sync"
+
+	"github.com/basecomplextech/baselibrary/async/internal/pool"
+	"github.com/basecomplextech/baselibrary/collect/slices2"
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// Executor runs routines with a concurrency limit, and queues the rest.
+//
+// Routines are submitted via [Execute] and [ExecuteVoid], and are started by the executor,
+// they must not be started manually. Queued routines can be stopped, they are skipped then.
+//
+// Usage:
+//
+//	e := async.NewExecutor(async.ExecutorOptions{Concurrency: 8, QueueSize: 1024})
+//	defer e.Stop()
+//
+//	r := async.Execute(e, func(ctx async.Context) (int, status.Status) {
+//		return 1, status.OK
+//	})
+type Executor interface {
+	// Stats returns the executor counters.
+	Stats() ExecutorStats
+
+	// Stop stops accepting new routines, rejects queued routines with a cancelled status,
+	// and returns a channel which is closed when the running routines complete.
+	Stop() <-chan struct{}
+
+	// Internal
+
+	// submit submits a routine to the executor.
+	submit(task executorTask)
+}
+
+// ExecutorOptions specifies the executor options.
+type ExecutorOptions struct {
+	// Concurrency is the max number of running routines, zero means one.
+	Concurrency int
+
+	// QueueSize is the max number of queued routines, zero means no queue.
+	QueueSize int
+
+	// Reject is the policy when the queue is full.
+	Reject RejectPolicy
+}
+
+// ExecutorStats contains the executor counters.
+type ExecutorStats struct {
+	Running   int   // number of running routines
+	Queued    int   // number of queued routines
+	Completed int64 // number of completed routines
+	Rejected  int64 // number of rejected routines
+}
+
+// RejectPolicy specifies how the executor handles routines when the queue is full.
+type RejectPolicy int
+
+const (
+	// RejectAbort rejects a new routine with an unavailable status.
+	RejectAbort RejectPolicy = iota
+
+	// RejectDropOldest rejects the oldest queued routine with an unavailable status,
+	// and queues a new one.
+	RejectDropOldest
+
+	// RejectCallerRuns runs a new routine in the caller goroutine.
+	RejectCallerRuns
+)
+
+// NewExecutor returns a new executor.
+func NewExecutor(opts ExecutorOptions) Executor {
+	return newExecutor(opts)
+}
+
+// Execute submits a function to the executor, and returns a routine.
+// The routine is rejected with an unavailable status if the queue is full,
+// or with a closed status if the executor is stopped.
+func Execute[T any](e Executor, fn Func[T]) Routine[T] {
+	r := newRoutine(fn)
+	e.submit(r)
+	return r
+}
+
+// ExecuteVoid submits a procedure to the executor, and returns a routine.
+// The routine is rejected with an unavailable status if the queue is full,
+// or with a closed status if the executor is stopped.
+func ExecuteVoid(e Executor, fn FuncVoid) RoutineVoid {
+	fn1 := func(ctx Context) (struct{}, status.Status) {
+		return struct{}{}, fn(ctx)
+	}
+
+	r := newRoutine(fn1)
+	e.submit(r)
+	return r
+}
+
+// internal
+
+var _ Executor = (*executor)(nil)
+
+var (
+	errExecutorFull    = status.Unavailable("executor queue is full")
+	errExecutorStopped = status.Closedf("executor is stopped")
+)
+
+type executor struct {
+	opts ExecutorOptions
+	pool pool.Pool
+
+	mu      sync.Mutex
+	queue   []executorTask
+	stopped bool
+	stop    chan struct{} // closed when stopped and no running routines
+
+	running   int
+	completed int64
+	rejected  int64
+}
+
+// executorTask is a type-erased routine.
+type executorTask interface {
+	tryStart() bool
+	run()
+	reject(st status.Status)
+}
+
+func newExecutor(opts ExecutorOptions) *executor {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.QueueSize < 0 {
+		opts.QueueSize = 0
+	}
+
+	return &executor{
+		opts: opts,
+		pool: pool.New(),
+		stop: make(chan struct{}),
+	}
+}
+
+// Stats returns the executor counters.
+func (e *executor) Stats() ExecutorStats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return ExecutorStats{
+		Running:   e.running,
+		Queued:    len(e.queue),
+		Completed: e.completed,
+		Rejected:  e.rejected,
+	}
+}
+
+// Stop stops accepting new routines, rejects queued routines with a cancelled status,
+// and returns a channel which is closed when the running routines complete.
+func (e *executor) Stop() <-chan struct{} {
+	e.mu.Lock()
+	if e.stopped {
+		e.mu.Unlock()
+		return e.stop
+	}
+
+	e.stopped = true
+	queue := e.queue
+	e.queue = nil
+	e.rejected += int64(len(queue))
+
+	if e.running == 0 {
+		close(e.stop)
+	}
+	e.mu.Unlock()
+
+	// Reject outside of lock
+	for _, task := range queue {
+		task.reject(status.Cancelled)
+	}
+	return e.stop
+}
+
+// private
+
+func (e *executor) submit(task executorTask) {
+	e.mu.Lock()
+
+	// Reject when stopped
+	if e.stopped {
+		e.rejected++
+		e.mu.Unlock()
+
+		task.reject(errExecutorStopped)
+		return
+	}
+
+	// Start worker
+	if e.running < e.opts.Concurrency {
+		e.running++
+		e.mu.Unlock()
+
+		e.pool.Go(func() {
+			e.work(task)
+		})
+		return
+	}
+
+	// Queue task
+	if len(e.queue) < e.opts.QueueSize {
+		e.queue = append(e.queue, task)
+		e.mu.Unlock()
+		return
+	}
+
+	// Handle full queue
+	switch e.opts.Reject {
+	case RejectDropOldest:
+		if len(e.queue) > 0 {
+			oldest := e.queue[0]
+			e.queue = slices2.ShiftLeft(e.queue, 1)
+			e.queue = append(e.queue, task)
+			e.rejected++
+			e.mu.Unlock()
+
+			oldest.reject(errExecutorFull)
+			return
+		}
+
+	case RejectCallerRuns:
+		e.mu.Unlock()
+
+		if !task.tryStart() {
+			return
+		}
+		task.run()
+
+		e.mu.Lock()
+		e.completed++
+		e.mu.Unlock()
+		return
+	}
+
+	e.rejected++
+	e.mu.Unlock()
+	task.reject(errExecutorFull)
+}
+
+// work runs a task and then the queued tasks, exits when the queue is empty.
+// Tasks stopped in the queue are skipped.
+func (e *executor) work(task executorTask) {
+	for {
+		ran := task.tryStart()
+		if ran {
+			task.run()
+		}
+
+		var ok bool
+		task, ok = e.next(ran)
+		if !ok {
+			return
+		}
+	}
+}
+
+// next completes the previous task and returns the next one, or decrements running.
+func (e *executor) next(ran bool) (executorTask, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if ran {
+		e.completed++
+	}
+
+	if len(e.queue) > 0 {
+		task := e.queue[0]
+		e.queue = slices2.ShiftLeft(e.queue, 1)
+		return task, true
+	}
+
+	e.running--
+	if e.running == 0 && e.stopped {
+		close(e.stop)
+	}
+	return nil, false
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testExecutorBlock(e Executor, release <-chan struct{}) RoutineVoid {
+	return ExecuteVoid(e, func(ctx Context) status.Status {
+		<-release
+		return status.OK
+	})
+}
+
+// Execute
+
+func TestExecute__should_run_routine_and_return_result(t *testing.T) {
+	e := NewExecutor(ExecutorOptions{Concurrency: 2})
+	defer e.Stop()
+
+	r := Execute(e, func(ctx Context) (int, status.Status) {
+		return 123, status.OK
+	})
+	<-r.Wait()
+
+	v, st := r.Result()
+	require.True(t, st.OK())
+	assert.Equal(t, 123, v)
+}
+
+func TestExecute__should_limit_concurrency(t *testing.T) {
+	e := NewExecutor(ExecutorOptions{Concurrency: 2, QueueSize: 100})
+	defer e.Stop()
+
+	var running atomic.Int32
+	var maxRunning atomic.Int32
+
+	routines := make([]RoutineVoid, 0, 20)
+	for i := 0; i < 20; i++ {
+		r := ExecuteVoid(e, func(ctx Context) status.Status {
+			n := running.Add(1)
+			defer running.Add(-1)
+
+			if n > maxRunning.Load() {
+				maxRunning.Store(n)
+			}
+			time.Sleep(time.Millisecond)
+			return status.OK
+		})
+		routines = append(routines, r)
+	}
+
+	for _, r := range routines {
+		<-r.Wait()
+		require.True(t, r.Status().OK())
+	}
+
+	assert.LessOrEqual(t, maxRunning.Load(), int32(2))
+	assert.Equal(t, int64(20), e.Stats().Completed)
+}
+
+func TestExecute__should_reject_routine_when_queue_full(t *testing.T) {
+	e := NewExecutor(ExecutorOptions{Concurrency: 1, QueueSize: 1})
+	defer e.Stop()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	testExecutorBlock(e, release)
+	testExecutorBlock(e, release)
+	r := testExecutorBlock(e, release)
+
+	<-r.Wait()
+	assert.Equal(t, status.CodeUnavailable, r.Status().Code)
+
+	stats := e.Stats()
+	assert.Equal(t, 1, stats.Running)
+	assert.Equal(t, 1, stats.Queued)
+	assert.Equal(t, int64(1), stats.Rejected)
+}
+
+func TestExecute__should_drop_oldest_queued_routine(t *testing.T) {
+	e := NewExecutor(ExecutorOptions{
+		Concurrency: 1,
+		QueueSize:   1,
+		Reject:      RejectDropOldest,
+	})
+	defer e.Stop()
+
+	release := make(chan struct{})
+	testExecutorBlock(e, release)
+	r0 := testExecutorBlock(e, release)
+	r1 := testExecutorBlock(e, release)
+
+	<-r0.Wait()
+	assert.Equal(t, status.CodeUnavailable, r0.Status().Code)
+
+	close(release)
+	<-r1.Wait()
+	assert.True(t, r1.Status().OK())
+}
+
+func TestExecute__should_run_routine_in_caller_when_queue_full(t *testing.T) {
+	e := NewExecutor(ExecutorOptions{
+		Concurrency: 1,
+		Reject:      RejectCallerRuns,
+	})
+	defer e.Stop()
+
+	release := make(chan struct{})
+	defer close(release)
+	testExecutorBlock(e, release)
+
+	r := Execute(e, func(ctx Context) (int, status.Status) {
+		return 1, status.OK
+	})
+	assert.True(t, r.Done())
+
+	v, st := r.Result()
+	require.True(t, st.OK())
+	assert.Equal(t, 1, v)
+}
+
+func TestExecute__should_skip_stopped_queued_routine(t *testing.T) {
+	e := NewExecutor(ExecutorOptions{Concurrency: 1, QueueSize: 1})
+	defer e.Stop()
+
+	release := make(chan struct{})
+	r0 := testExecutorBlock(e, release)
+
+	var ran atomic.Bool
+	r1 := ExecuteVoid(e, func(ctx Context) status.Status {
+		ran.Store(true)
+		return status.OK
+	})
+	<-r1.Stop()
+
+	close(release)
+	<-r0.Wait()
+
+	require.Eventually(t, func() bool {
+		return e.Stats().Running == 0
+	}, time.Second, time.Millisecond)
+	assert.False(t, ran.Load())
+	assert.Equal(t, status.Cancelled, r1.Status())
+}
+
+// Stop
+
+func TestExecutor_Stop__should_cancel_queued_and_wait_running_routines(t *testing.T) {
+	e := NewExecutor(ExecutorOptions{Concurrency: 1, QueueSize: 1})
+
+	release := make(chan struct{})
+	r0 := testExecutorBlock(e, release)
+	r1 := testExecutorBlock(e, release)
+
+	stop := e.Stop()
+	<-r1.Wait()
+	assert.Equal(t, status.Cancelled, r1.Status())
+
+	select {
+	case <-stop:
+		t.Fatal("executor stopped with running routines")
+	default:
+	}
+
+	close(release)
+	<-stop
+	assert.True(t, r0.Status().OK())
+}
+
+func TestExecutor_Stop__should_reject_new_routines(t *testing.T) {
+	e := NewExecutor(ExecutorOptions{})
+	<-e.Stop()
+
+	r := testExecutorBlock(e, nil)
+	<-r.Wait()
+	assert.Equal(t, status.CodeClosed, r.Status().Code)
+}
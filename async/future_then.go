@@ -0,0 +1,404 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"sync"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/collect/chans"
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// Future combinators are resolved lazily, the stage functions are called by the first goroutine
+// which observes the source completion via Done, Result or Wait. The outermost future drives
+// the whole chain, the inner lazy stages are advanced without starting goroutines.
+//
+// A goroutine is started only when Wait is called on a pending future, when the future has
+// a timeout, or when it holds pending reference counted futures, i.e. asyncrc futures.
+// Such futures are driven by the source completion, and release the reference counted
+// futures when resolved, even if not observed.
+//
+// The stage functions are called inside the future lock, they must not block.
+// A panic in a stage function rejects the future with the recovered status.
+
+// Then returns a future which calls fn with the source result on success, and resolves
+// with the result of the returned future. The source status is returned on error,
+// the future is rejected if fn returns nil.
+//
+// The future returned by fn is owned by the combinator, and is released when resolved
+// if it is reference counted.
+func Then[T, U any](f Future[T], fn func(T) Future[U]) Future[U] {
+	var x *lazyFuture[U]
+	var next Future[U]
+	var called bool
+
+	step := func() (U, status.Status, bool, <-chan struct{}) {
+		var zero U
+
+		// Wait source
+		if !called {
+			if done, wait := pollFuture(f); !done {
+				return zero, status.None, false, wait
+			}
+
+			v, st := f.Result()
+			if !st.OK() {
+				return zero, st, true, nil
+			}
+
+			called = true
+			next = fn(v)
+			if next == nil {
+				return zero, status.Errorf("then function returned nil future"), true, nil
+			}
+			if _, ok := next.(refcountedFuture); ok {
+				x.owns = true
+			}
+		}
+
+		// Wait next
+		if done, wait := pollFuture(next); !done {
+			return zero, status.None, false, wait
+		}
+
+		v, st := next.Result()
+		return v, st, true, nil
+	}
+
+	free := func() {
+		if next != nil {
+			releaseFuture(next)
+		}
+	}
+
+	x = newLazyFuture(step, free, f)
+	x.init()
+	return x
+}
+
+// Map returns a future which converts the source result with fn on success,
+// or returns the source status on error.
+func Map[T, U any](f Future[T], fn func(T) U) Future[U] {
+	step := func() (U, status.Status, bool, <-chan struct{}) {
+		var zero U
+		if done, wait := pollFuture(f); !done {
+			return zero, status.None, false, wait
+		}
+
+		v, st := f.Result()
+		if !st.OK() {
+			return zero, st, true, nil
+		}
+		return fn(v), status.OK, true, nil
+	}
+
+	x := newLazyFuture(step, nil, f)
+	x.init()
+	return x
+}
+
+// Catch returns a future which calls fn with the source status on error, and resolves
+// with its result. The source result is returned on success.
+func Catch[T any](f Future[T], fn func(status.Status) (T, status.Status)) Future[T] {
+	step := func() (T, status.Status, bool, <-chan struct{}) {
+		var zero T
+		if done, wait := pollFuture(f); !done {
+			return zero, status.None, false, wait
+		}
+
+		v, st := f.Result()
+		if st.OK() {
+			return v, st, true, nil
+		}
+
+		v, st = fn(st)
+		return v, st, true, nil
+	}
+
+	x := newLazyFuture(step, nil, f)
+	x.init()
+	return x
+}
+
+// WithTimeout returns a future which is resolved with the source result,
+// or is rejected with a timeout status if the source is not complete within the timeout.
+func WithTimeout[T any](f Future[T], timeout time.Duration) Future[T] {
+	step := func() (T, status.Status, bool, <-chan struct{}) {
+		var zero T
+		if done, wait := pollFuture(f); !done {
+			return zero, status.None, false, wait
+		}
+
+		v, st := f.Result()
+		return v, st, true, nil
+	}
+
+	x := newLazyFuture(step, nil, f)
+	x.setTimeout(timeout)
+	return x
+}
+
+// internal
+
+var _ Future[any] = (*lazyFuture[any])(nil)
+
+// lazyStep tries to resolve a future, returns a result and true when done,
+// or a channel to wait on for the next step.
+type lazyStep[T any] func() (T, status.Status, bool, <-chan struct{})
+
+// lazyPoller is implemented by lazy futures, see [pollFuture].
+type lazyPoller interface {
+	poll() (bool, <-chan struct{})
+}
+
+type lazyFuture[T any] struct {
+	mu      sync.Mutex
+	step    lazyStep[T]
+	free    func() // maybe nil
+	sources []any  // retained sources
+	timer   *time.Timer
+	owns    bool // holds reference counted futures, drives itself to release them
+	watch   bool // watch goroutine started
+	promise *promise[T]
+}
+
+func newLazyFuture[T any](step lazyStep[T], free func(), sources ...any) *lazyFuture[T] {
+	owns := false
+	for _, s := range sources {
+		if _, ok := s.(refcountedFuture); ok {
+			owns = true
+		}
+		retainFuture(s)
+	}
+
+	return &lazyFuture[T]{
+		step:    step,
+		free:    free,
+		sources: sources,
+		owns:    owns,
+		promise: newPromise[T](),
+	}
+}
+
+// Done returns true if the future is complete.
+func (x *lazyFuture[T]) Done() bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	done, _ := x.advance()
+	return done
+}
+
+// Wait returns a channel which is closed when the future is complete.
+func (x *lazyFuture[T]) Wait() <-chan struct{} {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	done, next := x.advance()
+	if done {
+		return chans.Closed()
+	}
+
+	x.startWatch(next)
+	return x.promise.Wait()
+}
+
+// Result returns a value and a status.
+func (x *lazyFuture[T]) Result() (T, status.Status) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.advance()
+	return x.promise.Result()
+}
+
+// Status returns a status or none.
+func (x *lazyFuture[T]) Status() status.Status {
+	_, st := x.Result()
+	return st
+}
+
+// private
+
+// init advances the future, so that it starts driving itself if it holds
+// reference counted futures.
+func (x *lazyFuture[T]) init() {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.advance()
+}
+
+// poll advances the future without starting a watch goroutine, returns true when done,
+// or a channel to wait on. The channel is the completion channel if the future drives itself,
+// or the next step channel otherwise.
+func (x *lazyFuture[T]) poll() (bool, <-chan struct{}) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	done, next := x.advance()
+	switch {
+	case done:
+		return true, nil
+	case x.watch:
+		return false, x.promise.Wait()
+	}
+	return false, next
+}
+
+// advance tries to resolve the future, returns true when done, or a channel to wait on.
+// The method rejects the future if a stage function panics.
+func (x *lazyFuture[T]) advance() (done bool, next <-chan struct{}) {
+	if x.promise.Done() {
+		return true, nil
+	}
+
+	defer func() {
+		if e := recover(); e != nil {
+			st := status.Recover(e)
+			x.reject(st)
+			done, next = true, nil
+		}
+	}()
+
+	v, st, ok, next := x.step()
+	if !ok {
+		if x.owns || x.timer != nil {
+			x.startWatch(next)
+		}
+		return false, next
+	}
+
+	x.complete(v, st)
+	return true, nil
+}
+
+// complete completes the promise, stops the timer and releases the sources.
+func (x *lazyFuture[T]) complete(v T, st status.Status) {
+	if !x.promise.Complete(v, st) {
+		return
+	}
+
+	if x.timer != nil {
+		x.timer.Stop()
+	}
+	if x.free != nil {
+		x.free()
+	}
+	for _, s := range x.sources {
+		releaseFuture(s)
+	}
+
+	x.step = nil
+	x.free = nil
+	x.sources = nil
+}
+
+func (x *lazyFuture[T]) reject(st status.Status) {
+	var zero T
+	x.complete(zero, st)
+}
+
+// watch
+
+// startWatch starts a watch goroutine if not started yet.
+func (x *lazyFuture[T]) startWatch(next <-chan struct{}) {
+	if x.watch {
+		return
+	}
+
+	x.watch = true
+	go x.watchLoop(next)
+}
+
+// watchLoop waits for the next steps until the future is complete,
+// rejects the future on panic.
+func (x *lazyFuture[T]) watchLoop(next <-chan struct{}) {
+	defer func() {
+		if e := recover(); e != nil {
+			st := status.Recover(e)
+
+			x.mu.Lock()
+			defer x.mu.Unlock()
+			x.reject(st)
+		}
+	}()
+
+	done := x.promise.Wait()
+	for {
+		select {
+		case <-next:
+		case <-done:
+			return
+		}
+
+		ok, next1 := x.watchStep()
+		if ok {
+			return
+		}
+		next = next1
+	}
+}
+
+func (x *lazyFuture[T]) watchStep() (bool, <-chan struct{}) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	return x.advance()
+}
+
+// setTimeout starts a timer which rejects the future with a timeout status,
+// the future drives itself then.
+func (x *lazyFuture[T]) setTimeout(timeout time.Duration) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	done, next := x.advance()
+	if done {
+		return
+	}
+
+	x.timer = time.AfterFunc(timeout, func() {
+		x.mu.Lock()
+		defer x.mu.Unlock()
+
+		x.reject(status.Timeout)
+	})
+	x.startWatch(next)
+}
+
+// pollFuture returns true if a future is done, or a channel to wait on.
+// Lazy futures are advanced without starting goroutines, so that the outermost future
+// drives the whole chain.
+func pollFuture[T any](f Future[T]) (bool, <-chan struct{}) {
+	if x, ok := f.(lazyPoller); ok {
+		return x.poll()
+	}
+
+	if f.Done() {
+		return true, nil
+	}
+	return false, f.Wait()
+}
+
+// refcount
+
+type refcountedFuture interface {
+	Retain()
+	Release()
+}
+
+func retainFuture(f any) {
+	if r, ok := f.(refcountedFuture); ok {
+		r.Retain()
+	}
+}
+
+func releaseFuture(f any) {
+	if r, ok := f.(refcountedFuture); ok {
+		r.Release()
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAwait[T any](t *testing.T, f Future[T]) (T, status.Status) {
+	t.Helper()
+
+	select {
+	case <-f.Wait():
+		return f.Result()
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+	panic("unreachable")
+}
+
+// Then
+
+func TestThen__should_chain_futures(t *testing.T) {
+	p0 := NewPromise[int]()
+	p1 := NewPromise[string]()
+
+	f := Then(p0, func(v int) Future[string] {
+		go p1.Resolve(strconv.Itoa(v))
+		return p1
+	})
+	assert.False(t, f.Done())
+
+	p0.Resolve(123)
+	v, st := testAwait(t, f)
+	require.True(t, st.OK())
+	assert.Equal(t, "123", v)
+}
+
+func TestThen__should_return_source_status_on_error(t *testing.T) {
+	called := false
+	f := Then(Rejected[int](status.Test("test")), func(v int) Future[string] {
+		called = true
+		return Resolved("")
+	})
+
+	_, st := f.Result()
+	assert.Equal(t, status.Test("test"), st)
+	assert.False(t, called)
+}
+
+func TestThen__should_reject_future_when_function_returns_nil(t *testing.T) {
+	f := Then(Resolved(1), func(v int) Future[string] {
+		return nil
+	})
+
+	_, st := f.Result()
+	assert.Equal(t, status.CodeError, st.Code)
+}
+
+// Map
+
+func TestMap__should_map_result_lazily(t *testing.T) {
+	p := NewPromise[int]()
+	f := Map(p, func(v int) string {
+		return strconv.Itoa(v)
+	})
+
+	_, st := f.Result()
+	assert.Equal(t, status.None, st)
+
+	p.Resolve(1)
+	v, st := f.Result()
+	require.True(t, st.OK())
+	assert.Equal(t, "1", v)
+}
+
+func TestMap__should_call_function_once(t *testing.T) {
+	var calls atomic.Int32
+	p := NewPromise[int]()
+	f := Map(p, func(v int) int {
+		calls.Add(1)
+		return v * 2
+	})
+
+	go p.Resolve(2)
+	v, st := testAwait(t, f)
+	require.True(t, st.OK())
+	assert.Equal(t, 4, v)
+
+	f.Result()
+	f.Done()
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestMap__should_reject_future_on_panic(t *testing.T) {
+	f := Map(Resolved(1), func(v int) int {
+		panic("test")
+	})
+
+	_, st := f.Result()
+	assert.Equal(t, status.CodeError, st.Code)
+
+	// Not locked
+	assert.True(t, f.Done())
+}
+
+func TestMap__should_reject_waited_future_on_panic(t *testing.T) {
+	p := NewPromise[int]()
+	f := Map(p, func(v int) int {
+		panic("test")
+	})
+	wait := f.Wait()
+
+	p.Resolve(1)
+	select {
+	case <-wait:
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+
+	_, st := f.Result()
+	assert.Equal(t, status.CodeError, st.Code)
+}
+
+func TestMap__should_drive_chain_from_outermost_future(t *testing.T) {
+	p := NewPromise[int]()
+	f0 := Map(p, func(v int) int { return v + 1 })
+	f1 := Map(f0, func(v int) int { return v * 2 })
+
+	go p.Resolve(1)
+	v, st := testAwait(t, f1)
+	require.True(t, st.OK())
+	assert.Equal(t, 4, v)
+	assert.False(t, f0.(*lazyFuture[int]).watch)
+}
+
+// Catch
+
+func TestCatch__should_recover_from_error(t *testing.T) {
+	f := Catch(Rejected[int](status.Test("test")), func(st status.Status) (int, status.Status) {
+		return 1, status.OK
+	})
+
+	v, st := f.Result()
+	require.True(t, st.OK())
+	assert.Equal(t, 1, v)
+}
+
+func TestCatch__should_return_result_on_success(t *testing.T) {
+	f := Catch(Resolved(2), func(st status.Status) (int, status.Status) {
+		return 1, status.OK
+	})
+
+	v, st := f.Result()
+	require.True(t, st.OK())
+	assert.Equal(t, 2, v)
+}
+
+// WithTimeout
+
+func TestWithTimeout__should_reject_future_on_timeout(t *testing.T) {
+	p := NewPromise[int]()
+	f := WithTimeout[int](p, 10*time.Millisecond)
+
+	_, st := testAwait(t, f)
+	assert.Equal(t, status.Timeout, st)
+}
+
+func TestWithTimeout__should_return_result_before_timeout(t *testing.T) {
+	p := NewPromise[int]()
+	f := WithTimeout[int](p, time.Second)
+
+	go p.Resolve(1)
+	v, st := testAwait(t, f)
+	require.True(t, st.OK())
+	assert.Equal(t, 1, v)
+}
+
+func TestWithTimeout__should_reject_chained_future_on_timeout(t *testing.T) {
+	p := NewPromise[int]()
+	f := Map(WithTimeout[int](p, 10*time.Millisecond), func(v int) int {
+		return v
+	})
+
+	_, st := testAwait(t, f)
+	assert.Equal(t, status.Timeout, st)
+}
+
+// Refcount
+
+func TestMap__should_retain_and_release_refcounted_source(t *testing.T) {
+	p := &testRefFuture[int]{promise: newPromise[int]()}
+	f := Map[int](p, func(v int) int { return v })
+	assert.Equal(t, int32(1), p.refs.Load())
+
+	p.promise.Resolve(1)
+	f.Result()
+	assert.Equal(t, int32(0), p.refs.Load())
+}
+
+func TestMap__should_release_refcounted_source_when_not_observed(t *testing.T) {
+	p := &testRefFuture[int]{promise: newPromise[int]()}
+	Map[int](p, func(v int) int { return v })
+	assert.Equal(t, int32(1), p.refs.Load())
+
+	p.promise.Resolve(1)
+	assert.Eventually(t, func() bool {
+		return p.refs.Load() == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestThen__should_release_refcounted_next_when_not_observed(t *testing.T) {
+	p := NewPromise[int]()
+	next := &testRefFuture[int]{promise: newPromise[int]()}
+	f := Then[int](p, func(v int) Future[int] {
+		next.Retain()
+		return next
+	})
+
+	p.Resolve(1)
+	f.Done()
+	assert.Equal(t, int32(1), next.refs.Load())
+
+	next.promise.Resolve(2)
+	assert.Eventually(t, func() bool {
+		return next.refs.Load() == 0
+	}, time.Second, time.Millisecond)
+}
+
+// private
+
+type testRefFuture[T any] struct {
+	*promise[T]
+	refs atomic.Int32
+}
+
+func (f *testRefFuture[T]) Retain()  { f.refs.Add(1) }
+func (f *testRefFuture[T]) Release() { f.refs.Add(-1) }
@@ -30,6 +30,14 @@ type Context interface {
 	// Status returns a cancellation status or OK.
 	Status() status.Status
 
+	// Values
+
+	// Deadline returns the context deadline, or false if there is no deadline.
+	Deadline() (time.Time, bool)
+
+	// Value returns a context value for a key, or nil.
+	Value(key any) any
+
 	// Callbacks
 
 	// AddCallback adds a callback.
@@ -106,6 +114,14 @@ func NextDeadline(parent Context, deadline time.Time) Context {
 	return newContextTimeout(parent, timeout)
 }
 
+// Values
+
+// WithValue returns a context with a value, the context shares cancellation with the parent,
+// and frees the parent when freed.
+func WithValue(parent Context, key any, value any) Context {
+	return newValueContext(parent, key, value)
+}
+
 // Standard
 
 // Std returns a standard library context from an async one.
@@ -113,6 +129,12 @@ func Std(ctx Context) context_.Context {
 	return newStdContext(ctx)
 }
 
+// FromStd returns a cancellable context from a standard library one, the context must be freed.
+// The context is cancelled when the standard context is done, inherits its deadline and values.
+func FromStd(std context_.Context) CancelContext {
+	return newFromStdContext(std)
+}
+
 // internal
 
 var _ CancelContext = (*context)(nil)
@@ -121,6 +143,15 @@ type context struct {
 	refs  ref.Atomic32 // 1 by default, with released bit
 	freed atomic.Bool  // free only once
 	state atomic.Pointer[state]
+
+	// immutable
+	values   valuer    // maybe nil
+	deadline time.Time // zero if none
+}
+
+// valuer is implemented by async and standard contexts.
+type valuer interface {
+	Value(key any) any
 }
 
 func newContext(parent Context) *context {
@@ -130,6 +161,12 @@ func newContext(parent Context) *context {
 	x.refs.Init(1)
 	x.state.Store(s)
 
+	// Inherit values and deadline
+	if parent != nil {
+		x.values = parent
+		x.deadline, _ = parent.Deadline()
+	}
+
 	// Maybe add callback
 	if parent != nil {
 		parent.AddCallback(x)
@@ -140,6 +177,12 @@ func newContext(parent Context) *context {
 func newContextTimeout(parent Context, timeout time.Duration) *context {
 	x := newContext(parent)
 
+	// Set deadline
+	deadline := time.Now().Add(timeout)
+	if x.deadline.IsZero() || deadline.Before(x.deadline) {
+		x.deadline = deadline
+	}
+
 	// Maybe already timed out
 	if timeout <= 0 {
 		x.timeout()
@@ -193,6 +236,21 @@ func (x *context) Status() status.Status {
 	return st
 }
 
+// Values
+
+// Deadline returns the context deadline, or false if there is no deadline.
+func (x *context) Deadline() (time.Time, bool) {
+	return x.deadline, !x.deadline.IsZero()
+}
+
+// Value returns a context value for a key, or nil.
+func (x *context) Value(key any) any {
+	if x.values == nil {
+		return nil
+	}
+	return x.values.Value(key)
+}
+
 // Callbacks
 
 // AddCallback adds a callback.
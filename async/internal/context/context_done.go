@@ -5,6 +5,8 @@
 package context
 
 import (
+	"time"
+
 	"github.com/basecomplextech/baselibrary/collect/chans"
 	"github.com/basecomplextech/baselibrary/status"
 )
@@ -13,10 +15,12 @@ var done CancelContext = &doneContext{}
 
 type doneContext struct{}
 
-func (*doneContext) Cancel()                    {}
-func (*doneContext) Done() bool                 { return true }
-func (*doneContext) Wait() <-chan struct{}      { return chans.Closed() }
-func (*doneContext) Status() status.Status      { return status.OK }
-func (*doneContext) AddCallback(cb Callback)    { cb.OnCancelled(status.Cancelled) }
-func (*doneContext) RemoveCallback(cb Callback) {}
-func (*doneContext) Free()                      {}
+func (*doneContext) Cancel()                     {}
+func (*doneContext) Done() bool                  { return true }
+func (*doneContext) Wait() <-chan struct{}       { return chans.Closed() }
+func (*doneContext) Status() status.Status       { return status.OK }
+func (*doneContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (*doneContext) Value(key any) any           { return nil }
+func (*doneContext) AddCallback(cb Callback)     { cb.OnCancelled(status.Cancelled) }
+func (*doneContext) RemoveCallback(cb Callback)  {}
+func (*doneContext) Free()                       {}
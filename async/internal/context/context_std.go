@@ -23,7 +23,7 @@ func newStdContext(ctx Context) *stdContext {
 
 // Deadline returns the time when work done on behalf of this context should be cancelled.
 func (x *stdContext) Deadline() (deadline time.Time, ok bool) {
-	return
+	return x.ctx.Deadline()
 }
 
 // Done returns a channel that's closed when work done on behalf of this context should be cancelled.
@@ -46,5 +46,5 @@ func (x *stdContext) Err() error {
 // Value returns the value associated with this context for key, or nil
 // if no value is associated with key.
 func (x *stdContext) Value(key any) any {
-	return nil
+	return x.ctx.Value(key)
 }
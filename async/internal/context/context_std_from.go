@@ -0,0 +1,61 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package context
+
+import (
+	context_ "context"
+	"errors"
+
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+var _ CancelContext = (*fromStdContext)(nil)
+
+// fromStdContext is an async context which is cancelled when a standard context is done.
+type fromStdContext struct {
+	*context
+	std  context_.Context
+	stop func() bool // stops the standard context callback
+}
+
+func newFromStdContext(std context_.Context) *fromStdContext {
+	// Unwrap async context
+	if s, ok := std.(*stdContext); ok {
+		return &fromStdContext{
+			context: newContext(s.ctx),
+			std:     std,
+			stop:    func() bool { return false },
+		}
+	}
+
+	x := &fromStdContext{
+		context: newContext(nil /* no parent */),
+		std:     std,
+	}
+	x.values = std
+	x.deadline, _ = std.Deadline()
+
+	// Cancel on std done, stop callback on cancel
+	x.stop = context_.AfterFunc(std, x.onStdDone)
+	x.context.AddCallback(x)
+	return x
+}
+
+// OnCancelled is called when the context is cancelled.
+func (x *fromStdContext) OnCancelled(st status.Status) {
+	x.stop()
+}
+
+// private
+
+func (x *fromStdContext) onStdDone() {
+	err := x.std.Err()
+	switch {
+	case errors.Is(err, context_.DeadlineExceeded):
+		x.cancel(status.Timeout)
+	default:
+		x.cancel(status.Cancelled)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package context
+
+var _ Context = (*valueContext)(nil)
+
+// valueContext adds a value to a parent context, and delegates everything else to it.
+// The value context retains the parent, and frees it when freed.
+type valueContext struct {
+	Context
+	key   any
+	value any
+}
+
+func newValueContext(parent Context, key any, value any) *valueContext {
+	if key == nil {
+		panic("nil context key")
+	}
+
+	return &valueContext{
+		Context: parent,
+		key:     key,
+		value:   value,
+	}
+}
+
+// Value returns a context value for a key, or nil.
+func (x *valueContext) Value(key any) any {
+	if x.key == key {
+		return x.value
+	}
+	return x.Context.Value(key)
+}
+
+// Free frees the parent context.
+func (x *valueContext) Free() {
+	x.Context.Free()
+}
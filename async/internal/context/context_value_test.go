@@ -0,0 +1,182 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package context
+
+import (
+	context_ "context"
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testKey struct{}
+
+type testKey1 struct{}
+
+// WithValue
+
+func TestWithValue__should_return_value(t *testing.T) {
+	ctx := WithValue(No(), testKey{}, "value")
+	ctx = WithValue(ctx, testKey1{}, 123)
+
+	assert.Equal(t, "value", ctx.Value(testKey{}))
+	assert.Equal(t, 123, ctx.Value(testKey1{}))
+	assert.Nil(t, ctx.Value("other"))
+}
+
+func TestWithValue__should_share_cancellation_with_parent(t *testing.T) {
+	parent := New()
+	defer parent.Free()
+
+	ctx := WithValue(parent, testKey{}, "value")
+	assert.False(t, ctx.Done())
+
+	parent.Cancel()
+	assert.True(t, ctx.Done())
+}
+
+func TestWithValue_Free__should_free_parent(t *testing.T) {
+	parent := New()
+	ctx := WithValue(parent, testKey{}, "value")
+
+	ctx.Free()
+	assert.True(t, parent.Done())
+}
+
+func TestNext__should_inherit_values(t *testing.T) {
+	parent := WithValue(No(), testKey{}, "value")
+
+	ctx := Next(parent)
+	defer ctx.Free()
+
+	assert.Equal(t, "value", ctx.Value(testKey{}))
+}
+
+// Deadline
+
+func TestDeadline__should_return_deadline(t *testing.T) {
+	deadline := time.Now().Add(time.Minute)
+
+	ctx := Deadline(deadline)
+	defer ctx.Free()
+
+	d, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, deadline, d, 10*time.Millisecond)
+
+	_, ok = No().Deadline()
+	assert.False(t, ok)
+}
+
+func TestNextTimeout__should_inherit_earlier_parent_deadline(t *testing.T) {
+	parent := Timeout(time.Minute)
+	defer parent.Free()
+
+	ctx := NextTimeout(parent, time.Hour)
+	defer ctx.Free()
+
+	d0, _ := parent.Deadline()
+	d1, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.Equal(t, d0, d1)
+
+	ctx1 := NextTimeout(parent, time.Second)
+	defer ctx1.Free()
+
+	d2, _ := ctx1.Deadline()
+	assert.True(t, d2.Before(d0))
+}
+
+// Std
+
+func TestStd__should_return_deadline_and_values(t *testing.T) {
+	ctx := Timeout(time.Minute)
+	defer ctx.Free()
+
+	std := Std(WithValue(ctx, testKey{}, "value"))
+
+	d0, _ := ctx.Deadline()
+	d1, ok := std.Deadline()
+	require.True(t, ok)
+	assert.Equal(t, d0, d1)
+	assert.Equal(t, "value", std.Value(testKey{}))
+}
+
+// FromStd
+
+func TestFromStd__should_cancel_when_std_cancelled(t *testing.T) {
+	std, cancel := context_.WithCancel(context_.Background())
+
+	ctx := FromStd(std)
+	defer ctx.Free()
+
+	cancel()
+	select {
+	case <-ctx.Wait():
+	case <-time.After(time.Second):
+		t.Fatal("context was not cancelled")
+	}
+	assert.Equal(t, status.Cancelled, ctx.Status())
+}
+
+func TestFromStd__should_timeout_when_std_deadline_exceeded(t *testing.T) {
+	std, cancel := context_.WithTimeout(context_.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	ctx := FromStd(std)
+	defer ctx.Free()
+
+	d0, _ := std.Deadline()
+	d1, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.Equal(t, d0, d1)
+
+	select {
+	case <-ctx.Wait():
+	case <-time.After(time.Second):
+		t.Fatal("context was not cancelled")
+	}
+	assert.Equal(t, status.Timeout, ctx.Status())
+}
+
+func TestFromStd__should_return_std_values(t *testing.T) {
+	std := context_.WithValue(context_.Background(), testKey{}, "value")
+
+	ctx := FromStd(std)
+	defer ctx.Free()
+
+	assert.Equal(t, "value", ctx.Value(testKey{}))
+}
+
+func TestFromStd__should_cancel_std_view_when_cancelled(t *testing.T) {
+	ctx := FromStd(context_.Background())
+	std := Std(ctx)
+
+	ctx.Cancel()
+	select {
+	case <-std.Done():
+	case <-time.After(time.Second):
+		t.Fatal("std context was not cancelled")
+	}
+	assert.Equal(t, context_.Canceled, std.Err())
+	ctx.Free()
+}
+
+func TestFromStd__should_unwrap_async_context(t *testing.T) {
+	parent := New()
+	defer parent.Free()
+	parent1 := WithValue(parent, testKey{}, "value")
+
+	ctx := FromStd(Std(parent1))
+	defer ctx.Free()
+
+	assert.Equal(t, "value", ctx.Value(testKey{}))
+
+	parent.Cancel()
+	assert.True(t, ctx.Done())
+}
@@ -0,0 +1,282 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lock
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/basecomplextech/baselibrary/async/internal/context"
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+var _ sync.Locker = (RWLock)(nil)
+
+// RWLock is a reader/writer lock with cancellable acquisition.
+//
+// RWLock prefers writers, new readers block while a writer is waiting, so writers cannot starve.
+// Readers waiting for a writer are admitted together when the writer unlocks.
+//
+// Example:
+//
+//	lock := async.NewRWLock()
+//	if st := lock.RLockContext(ctx); !st.OK() {
+//		return st
+//	}
+//	defer lock.RUnlock()
+type RWLock interface {
+	// Lock locks the lock for writing.
+	Lock()
+
+	// LockContext awaits and locks the lock for writing, or awaits the context cancellation.
+	LockContext(ctx context.Context) status.Status
+
+	// TryLock locks the lock for writing without blocking, returns false if the lock is held.
+	TryLock() bool
+
+	// Unlock unlocks the lock for writing, panics if the lock is not write-locked.
+	Unlock()
+
+	// Read
+
+	// RLock locks the lock for reading.
+	RLock()
+
+	// RLockContext awaits and locks the lock for reading, or awaits the context cancellation.
+	RLockContext(ctx context.Context) status.Status
+
+	// TryRLock locks the lock for reading without blocking, returns false if the lock
+	// is write-locked or a writer is waiting.
+	TryRLock() bool
+
+	// RUnlock unlocks the lock for reading, panics if the lock is not read-locked.
+	RUnlock()
+}
+
+// NewRWLock returns a new unlocked read/write lock.
+func NewRWLock() RWLock {
+	return newRWLock()
+}
+
+// internal
+
+var _ RWLock = (*rwlock)(nil)
+
+type rwlock struct {
+	mu     sync.Mutex
+	writer bool // write-locked
+	rcount int  // number of readers
+
+	writers []chan struct{} // waiting writers, closed when locked
+	rwait   chan struct{}   // waiting readers, closed when locked, lazily allocated
+	rwaitN  int             // number of waiting readers
+}
+
+func newRWLock() *rwlock {
+	return &rwlock{}
+}
+
+// Lock locks the lock for writing.
+func (l *rwlock) Lock() {
+	l.LockContext(context.No())
+}
+
+// LockContext awaits and locks the lock for writing, or awaits the context cancellation.
+func (l *rwlock) LockContext(ctx context.Context) status.Status {
+	l.mu.Lock()
+
+	// Fast path
+	if l.canLock() {
+		l.writer = true
+		l.mu.Unlock()
+		return status.OK
+	}
+
+	// Add waiter
+	ready := make(chan struct{})
+	l.writers = append(l.writers, ready)
+	l.mu.Unlock()
+
+	// Await lock
+	select {
+	case <-ready:
+		return status.OK
+	case <-ctx.Wait():
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Unlock when locked concurrently
+	select {
+	case <-ready:
+		l.unlock()
+		return ctx.Status()
+	default:
+	}
+
+	// Remove waiter, admit readers if no more writers
+	i := slices.Index(l.writers, ready)
+	l.writers = slices.Delete(l.writers, i, i+1)
+	if len(l.writers) == 0 && !l.writer {
+		l.notifyReaders()
+	}
+	return ctx.Status()
+}
+
+// TryLock locks the lock for writing without blocking, returns false if the lock is held.
+func (l *rwlock) TryLock() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.canLock() {
+		return false
+	}
+
+	l.writer = true
+	return true
+}
+
+// Unlock unlocks the lock for writing, panics if the lock is not write-locked.
+func (l *rwlock) Unlock() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.writer {
+		panic("unlock of unlocked rwlock")
+	}
+	l.unlock()
+}
+
+// Read
+
+// RLock locks the lock for reading.
+func (l *rwlock) RLock() {
+	l.RLockContext(context.No())
+}
+
+// RLockContext awaits and locks the lock for reading, or awaits the context cancellation.
+func (l *rwlock) RLockContext(ctx context.Context) status.Status {
+	l.mu.Lock()
+
+	// Fast path
+	if l.canRLock() {
+		l.rcount++
+		l.mu.Unlock()
+		return status.OK
+	}
+
+	// Add waiter
+	if l.rwait == nil {
+		l.rwait = make(chan struct{})
+	}
+	ready := l.rwait
+	l.rwaitN++
+	l.mu.Unlock()
+
+	// Await lock
+	select {
+	case <-ready:
+		return status.OK
+	case <-ctx.Wait():
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Unlock when locked concurrently
+	select {
+	case <-ready:
+		l.runlock()
+		return ctx.Status()
+	default:
+	}
+
+	// Remove waiter
+	l.rwaitN--
+	return ctx.Status()
+}
+
+// TryRLock locks the lock for reading without blocking, returns false if the lock
+// is write-locked or a writer is waiting.
+func (l *rwlock) TryRLock() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.canRLock() {
+		return false
+	}
+
+	l.rcount++
+	return true
+}
+
+// RUnlock unlocks the lock for reading, panics if the lock is not read-locked.
+func (l *rwlock) RUnlock() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rcount == 0 {
+		panic("runlock of unlocked rwlock")
+	}
+	l.runlock()
+}
+
+// private
+
+func (l *rwlock) canLock() bool {
+	return !l.writer && l.rcount == 0 && len(l.writers) == 0
+}
+
+func (l *rwlock) canRLock() bool {
+	return !l.writer && len(l.writers) == 0
+}
+
+// unlock releases the write lock, admits waiting readers first, or passes the lock
+// to the next writer.
+func (l *rwlock) unlock() {
+	l.writer = false
+
+	if l.rwaitN > 0 {
+		l.notifyReaders()
+		return
+	}
+	l.notifyWriter()
+}
+
+// runlock releases the read lock, passes the lock to the next writer when no more readers.
+func (l *rwlock) runlock() {
+	l.rcount--
+
+	if l.rcount == 0 {
+		l.notifyWriter()
+	}
+}
+
+// notifyReaders admits all waiting readers.
+func (l *rwlock) notifyReaders() {
+	if l.rwaitN == 0 {
+		return
+	}
+
+	l.rcount += l.rwaitN
+	l.rwaitN = 0
+
+	close(l.rwait)
+	l.rwait = nil
+}
+
+// notifyWriter passes the lock to the first waiting writer.
+func (l *rwlock) notifyWriter() {
+	if len(l.writers) == 0 {
+		return
+	}
+
+	ready := l.writers[0]
+	l.writers = slices.Delete(l.writers, 0, 1)
+
+	l.writer = true
+	close(ready)
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/async/internal/context"
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testLock(l *rwlock) <-chan status.Status {
+	ch := make(chan status.Status, 1)
+	go func() {
+		ch <- l.LockContext(context.No())
+	}()
+	return ch
+}
+
+func testRLock(l *rwlock) <-chan status.Status {
+	ch := make(chan status.Status, 1)
+	go func() {
+		ch <- l.RLockContext(context.No())
+	}()
+	return ch
+}
+
+func testRWaiters(t *testing.T, l *rwlock, writers int, readers int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return len(l.writers) == writers && l.rwaitN == readers
+	}, time.Second, time.Millisecond)
+}
+
+// Lock
+
+func TestRWLock_Lock__should_wait_for_readers(t *testing.T) {
+	l := newRWLock()
+	l.RLock()
+	l.RLock()
+
+	ch := testLock(l)
+	testRWaiters(t, l, 1, 0)
+
+	l.RUnlock()
+	l.RUnlock()
+
+	st := <-ch
+	require.True(t, st.OK())
+	assert.True(t, l.writer)
+}
+
+func TestRWLock_LockContext__should_return_status_when_cancelled(t *testing.T) {
+	l := newRWLock()
+	l.RLock()
+
+	ctx := context.New()
+	defer ctx.Free()
+
+	ch := make(chan status.Status, 1)
+	go func() {
+		ch <- l.LockContext(ctx)
+	}()
+	testRWaiters(t, l, 1, 0)
+
+	ctx.Cancel()
+	st := <-ch
+	assert.Equal(t, status.Cancelled, st)
+
+	l.RUnlock()
+	assert.True(t, l.TryLock())
+}
+
+func TestRWLock_LockContext__should_admit_readers_when_last_writer_cancelled(t *testing.T) {
+	l := newRWLock()
+	l.RLock()
+
+	ctx := context.New()
+	defer ctx.Free()
+
+	go l.LockContext(ctx)
+	testRWaiters(t, l, 1, 0)
+
+	reader := testRLock(l)
+	testRWaiters(t, l, 1, 1)
+
+	ctx.Cancel()
+	st := <-reader
+	require.True(t, st.OK())
+	assert.Equal(t, 2, l.rcount)
+}
+
+// Unlock
+
+func TestRWLock_Unlock__should_admit_waiting_readers_before_writers(t *testing.T) {
+	l := newRWLock()
+	l.Lock()
+
+	writer := testLock(l)
+	testRWaiters(t, l, 1, 0)
+	r0 := testRLock(l)
+	r1 := testRLock(l)
+	testRWaiters(t, l, 1, 2)
+
+	l.Unlock()
+	require.True(t, (<-r0).OK())
+	require.True(t, (<-r1).OK())
+	assert.Equal(t, 2, l.rcount)
+
+	l.RUnlock()
+	l.RUnlock()
+	require.True(t, (<-writer).OK())
+}
+
+func TestRWLock_Unlock__should_panic_when_not_locked(t *testing.T) {
+	l := newRWLock()
+
+	assert.Panics(t, func() {
+		l.Unlock()
+	})
+}
+
+// RLock
+
+func TestRWLock_RLock__should_allow_multiple_readers(t *testing.T) {
+	l := newRWLock()
+
+	l.RLock()
+	l.RLock()
+	assert.Equal(t, 2, l.rcount)
+	assert.False(t, l.TryLock())
+}
+
+func TestRWLock_RLock__should_block_when_writer_waiting(t *testing.T) {
+	l := newRWLock()
+	l.RLock()
+
+	writer := testLock(l)
+	testRWaiters(t, l, 1, 0)
+
+	assert.False(t, l.TryRLock())
+	reader := testRLock(l)
+	testRWaiters(t, l, 1, 1)
+
+	l.RUnlock()
+	require.True(t, (<-writer).OK())
+
+	l.Unlock()
+	require.True(t, (<-reader).OK())
+}
+
+func TestRWLock_RLockContext__should_not_leak_lock_on_cancel(t *testing.T) {
+	l := newRWLock()
+	l.Lock()
+
+	ctx := context.New()
+	defer ctx.Free()
+
+	ch := make(chan status.Status, 1)
+	go func() {
+		ch <- l.RLockContext(ctx)
+	}()
+	testRWaiters(t, l, 0, 1)
+
+	ctx.Cancel()
+	st := <-ch
+	assert.Equal(t, status.Cancelled, st)
+
+	l.Unlock()
+	assert.Equal(t, 0, l.rcount)
+	assert.True(t, l.TryLock())
+}
+
+// RUnlock
+
+func TestRWLock_RUnlock__should_panic_when_not_locked(t *testing.T) {
+	l := newRWLock()
+
+	assert.Panics(t, func() {
+		l.RUnlock()
+	})
+}
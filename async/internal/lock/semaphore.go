@@ -0,0 +1,162 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lock
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/basecomplextech/baselibrary/async/internal/context"
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// Semaphore is a weighted semaphore which grants permits to waiters in FIFO order.
+//
+// A large waiter at the front blocks smaller waiters behind it, so waiters cannot starve.
+// Cancelled waiters do not leak permits.
+//
+// Example:
+//
+//	sem := async.NewSemaphore(10)
+//	if st := sem.Acquire(ctx, 3); !st.OK() {
+//		return st
+//	}
+//	defer sem.Release(3)
+type Semaphore interface {
+	// Acquire acquires n permits, blocks until they are available or the context is cancelled.
+	// The method returns an error status if n exceeds the semaphore size.
+	Acquire(ctx context.Context, n int) status.Status
+
+	// TryAcquire acquires n permits without blocking, returns false if not available
+	// or if there are other waiters.
+	TryAcquire(n int) bool
+
+	// Release releases n permits, panics if more permits are released than acquired.
+	Release(n int)
+}
+
+// NewSemaphore returns a new semaphore with the given number of permits.
+func NewSemaphore(size int) Semaphore {
+	return newSemaphore(size)
+}
+
+// internal
+
+var _ Semaphore = (*semaphore)(nil)
+
+type semaphore struct {
+	size int
+
+	mu      sync.Mutex
+	cur     int // acquired permits
+	waiters []*semaphoreWaiter
+}
+
+type semaphoreWaiter struct {
+	n     int
+	ready chan struct{} // closed when permits are granted
+}
+
+func newSemaphore(size int) *semaphore {
+	return &semaphore{size: size}
+}
+
+// Acquire acquires n permits, blocks until they are available or the context is cancelled.
+// The method returns an error status if n exceeds the semaphore size.
+func (s *semaphore) Acquire(ctx context.Context, n int) status.Status {
+	s.mu.Lock()
+
+	// Fast path
+	if s.size-s.cur >= n && len(s.waiters) == 0 {
+		s.cur += n
+		s.mu.Unlock()
+		return status.OK
+	}
+
+	// Check size
+	if n > s.size {
+		s.mu.Unlock()
+		return status.Errorf("semaphore: acquire %d permits exceeds size %d", n, s.size)
+	}
+
+	// Add waiter
+	w := &semaphoreWaiter{n: n, ready: make(chan struct{})}
+	s.waiters = append(s.waiters, w)
+	s.mu.Unlock()
+
+	// Await permits
+	select {
+	case <-w.ready:
+		return status.OK
+	case <-ctx.Wait():
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Release permits when granted concurrently
+	select {
+	case <-w.ready:
+		s.cur -= n
+		s.notify()
+		return ctx.Status()
+	default:
+	}
+
+	// Remove waiter, notify next waiters if it was the first one
+	i := slices.Index(s.waiters, w)
+	s.waiters = slices.Delete(s.waiters, i, i+1)
+	if i == 0 {
+		s.notify()
+	}
+	return ctx.Status()
+}
+
+// TryAcquire acquires n permits without blocking, returns false if not available
+// or if there are other waiters.
+func (s *semaphore) TryAcquire(n int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size-s.cur < n || len(s.waiters) > 0 {
+		return false
+	}
+
+	s.cur += n
+	return true
+}
+
+// Release releases n permits, panics if more permits are released than acquired.
+func (s *semaphore) Release(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cur -= n
+	if s.cur < 0 {
+		panic("semaphore: released more than held")
+	}
+
+	s.notify()
+}
+
+// private
+
+// notify grants permits to waiters in FIFO order, stops at the first waiter which does not fit.
+func (s *semaphore) notify() {
+	i := 0
+	for ; i < len(s.waiters); i++ {
+		w := s.waiters[i]
+		if s.size-s.cur < w.n {
+			break
+		}
+
+		s.cur += w.n
+		close(w.ready)
+	}
+
+	if i > 0 {
+		s.waiters = slices.Delete(s.waiters, 0, i)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/async/internal/context"
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAcquire(s *semaphore, n int) <-chan status.Status {
+	ch := make(chan status.Status, 1)
+	go func() {
+		ch <- s.Acquire(context.No(), n)
+	}()
+	return ch
+}
+
+func testWaiters(t *testing.T, s *semaphore, n int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.waiters) == n
+	}, time.Second, time.Millisecond)
+}
+
+// Acquire
+
+func TestSemaphore_Acquire__should_acquire_permits(t *testing.T) {
+	s := newSemaphore(3)
+
+	st := s.Acquire(context.No(), 2)
+	require.True(t, st.OK())
+	assert.Equal(t, 2, s.cur)
+
+	s.Release(2)
+	assert.Equal(t, 0, s.cur)
+}
+
+func TestSemaphore_Acquire__should_block_until_released(t *testing.T) {
+	s := newSemaphore(3)
+	s.Acquire(context.No(), 2)
+
+	ch := testAcquire(s, 2)
+	testWaiters(t, s, 1)
+
+	s.Release(2)
+	st := <-ch
+	require.True(t, st.OK())
+	assert.Equal(t, 2, s.cur)
+}
+
+func TestSemaphore_Acquire__should_grant_permits_in_fifo_order(t *testing.T) {
+	s := newSemaphore(3)
+	s.Acquire(context.No(), 3)
+
+	large := testAcquire(s, 3)
+	testWaiters(t, s, 1)
+	small := testAcquire(s, 1)
+	testWaiters(t, s, 2)
+
+	// Small waiter must not overtake large one
+	s.Release(1)
+	select {
+	case <-small:
+		t.Fatal("small waiter overtook large one")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	s.Release(2)
+	require.True(t, (<-large).OK())
+
+	s.Release(3)
+	require.True(t, (<-small).OK())
+}
+
+func TestSemaphore_Acquire__should_not_leak_permits_on_cancel(t *testing.T) {
+	s := newSemaphore(3)
+	s.Acquire(context.No(), 3)
+
+	ctx := context.New()
+	defer ctx.Free()
+
+	ch := make(chan status.Status, 1)
+	go func() {
+		ch <- s.Acquire(ctx, 2)
+	}()
+	testWaiters(t, s, 1)
+
+	ctx.Cancel()
+	st := <-ch
+	assert.Equal(t, status.Cancelled, st)
+
+	s.Release(3)
+	assert.Equal(t, 0, s.cur)
+	assert.Len(t, s.waiters, 0)
+}
+
+func TestSemaphore_Acquire__should_notify_next_waiters_when_first_cancelled(t *testing.T) {
+	s := newSemaphore(3)
+	s.Acquire(context.No(), 2)
+
+	ctx := context.New()
+	defer ctx.Free()
+
+	go s.Acquire(ctx, 3)
+	testWaiters(t, s, 1)
+	small := testAcquire(s, 1)
+	testWaiters(t, s, 2)
+
+	ctx.Cancel()
+	require.True(t, (<-small).OK())
+	assert.Equal(t, 3, s.cur)
+}
+
+func TestSemaphore_Acquire__should_return_error_when_exceeds_size(t *testing.T) {
+	s := newSemaphore(3)
+
+	st := s.Acquire(context.No(), 4)
+	assert.Equal(t, status.CodeError, st.Code)
+}
+
+// TryAcquire
+
+func TestSemaphore_TryAcquire__should_return_false_when_not_available(t *testing.T) {
+	s := newSemaphore(3)
+
+	ok := s.TryAcquire(2)
+	require.True(t, ok)
+
+	ok = s.TryAcquire(2)
+	assert.False(t, ok)
+}
+
+func TestSemaphore_TryAcquire__should_return_false_when_waiters(t *testing.T) {
+	s := newSemaphore(3)
+	s.Acquire(context.No(), 2)
+
+	testAcquire(s, 3)
+	testWaiters(t, s, 1)
+
+	ok := s.TryAcquire(1)
+	assert.False(t, ok)
+}
+
+// Release
+
+func TestSemaphore_Release__should_panic_when_released_more_than_held(t *testing.T) {
+	s := newSemaphore(3)
+
+	assert.Panics(t, func() {
+		s.Release(1)
+	})
+}
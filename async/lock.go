@@ -50,6 +50,34 @@ type Lock = lock.Lock
 //	}
 type WaitLock = lock.WaitLock
 
+// RWLock is a reader/writer lock with cancellable acquisition.
+//
+// RWLock prefers writers, new readers block while a writer is waiting, so writers cannot starve.
+// Readers waiting for a writer are admitted together when the writer unlocks.
+//
+// Example:
+//
+//	lock := async.NewRWLock()
+//	if st := lock.RLockContext(ctx); !st.OK() {
+//		return st
+//	}
+//	defer lock.RUnlock()
+type RWLock = lock.RWLock
+
+// Semaphore is a weighted semaphore which grants permits to waiters in FIFO order.
+//
+// A large waiter at the front blocks smaller waiters behind it, so waiters cannot starve.
+// Cancelled waiters do not leak permits.
+//
+// Example:
+//
+//	sem := async.NewSemaphore(10)
+//	if st := sem.Acquire(ctx, 3); !st.OK() {
+//		return st
+//	}
+//	defer sem.Release(3)
+type Semaphore = lock.Semaphore
+
 // New
 
 // NewLock returns a new unlocked lock.
@@ -61,3 +89,13 @@ func NewLock() Lock {
 func NewWaitLock() WaitLock {
 	return lock.NewWaitLock()
 }
+
+// NewRWLock returns a new unlocked read/write lock.
+func NewRWLock() RWLock {
+	return lock.NewRWLock()
+}
+
+// NewSemaphore returns a new semaphore with the given number of permits.
+func NewSemaphore(size int) Semaphore {
+	return lock.NewSemaphore(size)
+}
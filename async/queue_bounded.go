@@ -0,0 +1,282 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"sync"
+
+	"github.com/basecomplextech/baselibrary/collect/chans"
+	"github.com/basecomplextech/baselibrary/collect/slices2"
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// BoundedQueue is a FIFO queue with a max capacity guarded by a mutex.
+//
+// Writers block or fail when the queue is full. When the queue is closed,
+// writers receive an end status, readers receive the remaining elements and
+// then an end status.
+type BoundedQueue[T any] interface {
+	// Len returns the number of elements in the queue.
+	Len() int
+
+	// Cap returns the queue capacity.
+	Cap() int
+
+	// Closed returns true if the queue is closed.
+	Closed() bool
+
+	// Clear clears the queue.
+	Clear()
+
+	// Close closes the queue for writing, readers can still poll the remaining elements.
+	Close()
+
+	// Write
+
+	// TryPush adds an element to the queue, returns false if the queue is full,
+	// or an end status if the queue is closed.
+	TryPush(v T) (bool, status.Status)
+
+	// PushContext adds an element to the queue, blocks while the queue is full.
+	// The method returns an end status if the queue is closed, or a context status if cancelled.
+	PushContext(ctx Context, v T) status.Status
+
+	// WriteWait returns a channel which is notified when the queue is not full.
+	// The method returns a closed channel if the queue is not full or closed.
+	WriteWait() <-chan struct{}
+
+	// Read
+
+	// Poll removes an element from the queue, returns false if the queue is empty,
+	// or an end status if the queue is closed and empty.
+	Poll() (T, bool, status.Status)
+
+	// PollContext removes an element from the queue, blocks while the queue is empty.
+	// The method returns an end status if the queue is closed and empty,
+	// or a context status if cancelled.
+	PollContext(ctx Context) (T, status.Status)
+
+	// Wait returns a channel which is notified on new elements.
+	// The method returns a closed channel if the queue is not empty or closed.
+	Wait() <-chan struct{}
+}
+
+// NewBoundedQueue returns a new bounded queue, panics if the capacity is not positive.
+func NewBoundedQueue[T any](cap int) BoundedQueue[T] {
+	return newBoundedQueue[T](cap)
+}
+
+// internal
+
+var _ BoundedQueue[int] = (*boundedQueue[int])(nil)
+
+type boundedQueue[T any] struct {
+	cap int
+
+	mu        sync.Mutex
+	list      []T
+	closed    bool
+	readWait  chan struct{}
+	writeWait chan struct{}
+}
+
+func newBoundedQueue[T any](cap int) *boundedQueue[T] {
+	if cap <= 0 {
+		panic("queue capacity must be positive")
+	}
+
+	return &boundedQueue[T]{
+		cap:       cap,
+		list:      make([]T, 0, cap),
+		readWait:  make(chan struct{}, 1),
+		writeWait: make(chan struct{}, 1),
+	}
+}
+
+// Len returns the number of elements in the queue.
+func (q *boundedQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.list)
+}
+
+// Cap returns the queue capacity.
+func (q *boundedQueue[T]) Cap() int {
+	return q.cap
+}
+
+// Closed returns true if the queue is closed.
+func (q *boundedQueue[T]) Closed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.closed
+}
+
+// Clear clears the queue.
+func (q *boundedQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.list = slices2.Truncate(q.list)
+	q.notifyWrite()
+}
+
+// Close closes the queue for writing, readers can still poll the remaining elements.
+func (q *boundedQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+
+	// Wake up all waiters
+	q.closed = true
+	close(q.readWait)
+	close(q.writeWait)
+}
+
+// Write
+
+// TryPush adds an element to the queue, returns false if the queue is full,
+// or an end status if the queue is closed.
+func (q *boundedQueue[T]) TryPush(v T) (bool, status.Status) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	switch {
+	case q.closed:
+		return false, status.End
+	case len(q.list) >= q.cap:
+		return false, status.OK
+	}
+
+	q.list = append(q.list, v)
+	q.notifyRead()
+
+	// Pass notification to next writer
+	if len(q.list) < q.cap {
+		q.notifyWrite()
+	}
+	return true, status.OK
+}
+
+// PushContext adds an element to the queue, blocks while the queue is full.
+// The method returns an end status if the queue is closed, or a context status if cancelled.
+func (q *boundedQueue[T]) PushContext(ctx Context, v T) status.Status {
+	for {
+		ok, st := q.TryPush(v)
+		switch {
+		case !st.OK():
+			return st
+		case ok:
+			return status.OK
+		}
+
+		select {
+		case <-q.WriteWait():
+		case <-ctx.Wait():
+			return ctx.Status()
+		}
+	}
+}
+
+// WriteWait returns a channel which is notified when the queue is not full.
+// The method returns a closed channel if the queue is not full or closed.
+func (q *boundedQueue[T]) WriteWait() <-chan struct{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || len(q.list) < q.cap {
+		return chans.Closed()
+	}
+	return q.writeWait
+}
+
+// Read
+
+// Poll removes an element from the queue, returns false if the queue is empty,
+// or an end status if the queue is closed and empty.
+func (q *boundedQueue[T]) Poll() (v T, ok bool, st status.Status) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.list) == 0 {
+		if q.closed {
+			return v, false, status.End
+		}
+		return v, false, status.OK
+	}
+
+	// Get value, shift remaining left
+	v = q.list[0]
+	q.list = slices2.ShiftLeft(q.list, 1)
+	q.notifyWrite()
+
+	// Pass notification to next reader
+	if len(q.list) > 0 {
+		q.notifyRead()
+	}
+	return v, true, status.OK
+}
+
+// PollContext removes an element from the queue, blocks while the queue is empty.
+// The method returns an end status if the queue is closed and empty,
+// or a context status if cancelled.
+func (q *boundedQueue[T]) PollContext(ctx Context) (v T, st status.Status) {
+	for {
+		v, ok, st := q.Poll()
+		switch {
+		case !st.OK():
+			return v, st
+		case ok:
+			return v, status.OK
+		}
+
+		select {
+		case <-q.Wait():
+		case <-ctx.Wait():
+			return v, ctx.Status()
+		}
+	}
+}
+
+// Wait returns a channel which is notified on new elements.
+// The method returns a closed channel if the queue is not empty or closed.
+func (q *boundedQueue[T]) Wait() <-chan struct{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || len(q.list) > 0 {
+		return chans.Closed()
+	}
+	return q.readWait
+}
+
+// private
+
+func (q *boundedQueue[T]) notifyRead() {
+	if q.closed {
+		return
+	}
+
+	select {
+	case q.readWait <- struct{}{}:
+	default:
+	}
+}
+
+func (q *boundedQueue[T]) notifyWrite() {
+	if q.closed {
+		return
+	}
+
+	select {
+	case q.writeWait <- struct{}{}:
+	default:
+	}
+}
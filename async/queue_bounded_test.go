@@ -0,0 +1,196 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TryPush
+
+func TestBoundedQueue_TryPush__should_return_false_when_full(t *testing.T) {
+	q := newBoundedQueue[int](2)
+
+	ok, st := q.TryPush(1)
+	require.True(t, st.OK())
+	require.True(t, ok)
+
+	ok, st = q.TryPush(2)
+	require.True(t, st.OK())
+	require.True(t, ok)
+
+	ok, st = q.TryPush(3)
+	require.True(t, st.OK())
+	assert.False(t, ok)
+	assert.Equal(t, 2, q.Len())
+}
+
+func TestBoundedQueue_TryPush__should_return_end_when_closed(t *testing.T) {
+	q := newBoundedQueue[int](2)
+	q.Close()
+
+	ok, st := q.TryPush(1)
+	assert.False(t, ok)
+	assert.Equal(t, status.End, st)
+}
+
+// PushContext
+
+func TestBoundedQueue_PushContext__should_block_until_not_full(t *testing.T) {
+	q := newBoundedQueue[int](1)
+	q.TryPush(1)
+
+	ctx := NewContext()
+	defer ctx.Free()
+
+	done := make(chan status.Status, 1)
+	go func() {
+		done <- q.PushContext(ctx, 2)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("push did not block")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	v, ok, st := q.Poll()
+	require.True(t, st.OK())
+	require.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	st = <-done
+	require.True(t, st.OK())
+
+	v, _, _ = q.Poll()
+	assert.Equal(t, 2, v)
+}
+
+func TestBoundedQueue_PushContext__should_return_cancelled_when_context_cancelled(t *testing.T) {
+	q := newBoundedQueue[int](1)
+	q.TryPush(1)
+
+	ctx := NewContext()
+	defer ctx.Free()
+
+	done := make(chan status.Status, 1)
+	go func() {
+		done <- q.PushContext(ctx, 2)
+	}()
+
+	ctx.Cancel()
+	st := <-done
+	assert.Equal(t, status.Cancelled, st)
+	assert.Equal(t, 1, q.Len())
+}
+
+func TestBoundedQueue_PushContext__should_return_end_when_closed(t *testing.T) {
+	q := newBoundedQueue[int](1)
+	q.TryPush(1)
+
+	done := make(chan status.Status, 1)
+	go func() {
+		done <- q.PushContext(NoContext(), 2)
+	}()
+
+	q.Close()
+	st := <-done
+	assert.Equal(t, status.End, st)
+}
+
+func TestBoundedQueue_PushContext__should_wake_all_writers(t *testing.T) {
+	q := newBoundedQueue[int](4)
+	for i := 0; i < 4; i++ {
+		q.TryPush(i)
+	}
+
+	n := 4
+	done := make(chan status.Status, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			done <- q.PushContext(NoContext(), i)
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		q.PollContext(NoContext())
+	}
+	for i := 0; i < n; i++ {
+		st := <-done
+		require.True(t, st.OK())
+	}
+	assert.Equal(t, 4, q.Len())
+}
+
+// Poll
+
+func TestBoundedQueue_Poll__should_return_end_when_closed_and_drained(t *testing.T) {
+	q := newBoundedQueue[int](2)
+	q.TryPush(1)
+	q.Close()
+
+	v, ok, st := q.Poll()
+	require.True(t, st.OK())
+	require.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	_, ok, st = q.Poll()
+	assert.False(t, ok)
+	assert.Equal(t, status.End, st)
+}
+
+// PollContext
+
+func TestBoundedQueue_PollContext__should_block_until_element_pushed(t *testing.T) {
+	q := newBoundedQueue[int](1)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.TryPush(1)
+	}()
+
+	v, st := q.PollContext(NoContext())
+	require.True(t, st.OK())
+	assert.Equal(t, 1, v)
+}
+
+func TestBoundedQueue_PollContext__should_return_end_when_closed(t *testing.T) {
+	q := newBoundedQueue[int](1)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.Close()
+	}()
+
+	_, st := q.PollContext(NoContext())
+	assert.Equal(t, status.End, st)
+}
+
+// WriteWait
+
+func TestBoundedQueue_WriteWait__should_notify_when_not_full(t *testing.T) {
+	q := newBoundedQueue[int](1)
+	q.TryPush(1)
+
+	wait := q.WriteWait()
+	select {
+	case <-wait:
+		t.Fatal("unexpected notification")
+	default:
+	}
+
+	q.Poll()
+
+	select {
+	case <-wait:
+	default:
+		t.Fatal("expected notification")
+	}
+}
@@ -58,6 +58,20 @@ type (
 	FuncVoid1[A any] func(ctx Context, arg A) status.Status
 )
 
+// RoutineOptions specifies optional routine parameters.
+type RoutineOptions struct {
+	// Name is the routine name, it is set as the "routine" pprof label.
+	Name string
+
+	// Labels are additional pprof labels.
+	Labels map[string]string
+
+	// Parent is a parent context, the routine runs with its child context. The routine
+	// records the parent routine when the context is a routine context or its child.
+	// Nil means a new context.
+	Parent Context
+}
+
 // New
 
 // NewRoutine returns a new routine, but does not start it.
@@ -74,6 +88,20 @@ func NewRoutineVoid(fn func(ctx Context) status.Status) RoutineVoid {
 	return newRoutine(fn1)
 }
 
+// NewRoutineOpts returns a new routine with options, but does not start it.
+func NewRoutineOpts[T any](opts RoutineOptions, fn Func[T]) Routine[T] {
+	return newRoutineOpts(opts, fn)
+}
+
+// NewRoutineVoidOpts returns a new routine without a result with options, but does not start it.
+func NewRoutineVoidOpts(opts RoutineOptions, fn FuncVoid) RoutineVoid {
+	fn1 := func(ctx Context) (struct{}, status.Status) {
+		return struct{}{}, fn(ctx)
+	}
+
+	return newRoutineOpts(opts, fn1)
+}
+
 // Run
 
 // Run runs a function in a new routine, and returns the result, recovers on panics.
@@ -118,6 +146,39 @@ func RunVoid1[A any](fn FuncVoid1[A], arg A) RoutineVoid {
 	return r
 }
 
+// RunContext runs a function in a new routine with a child context of the parent,
+// the routine inherits the parent values and deadline, and is cancelled with the parent.
+func RunContext[T any](parent Context, fn Func[T]) Routine[T] {
+	opts := RoutineOptions{Parent: parent}
+	return RunOpts(opts, fn)
+}
+
+// RunVoidContext runs a procedure in a new routine with a child context of the parent,
+// the routine inherits the parent values and deadline, and is cancelled with the parent.
+func RunVoidContext(parent Context, fn FuncVoid) RoutineVoid {
+	opts := RoutineOptions{Parent: parent}
+	return RunVoidOpts(opts, fn)
+}
+
+// RunOpts runs a function in a new routine with options, and returns the result,
+// recovers on panics.
+func RunOpts[T any](opts RoutineOptions, fn Func[T]) Routine[T] {
+	r := newRoutineOpts(opts, fn)
+	r.Start()
+	return r
+}
+
+// RunVoidOpts runs a procedure in a new routine with options, recovers on panics.
+func RunVoidOpts(opts RoutineOptions, fn FuncVoid) RoutineVoid {
+	fn1 := func(ctx Context) (struct{}, status.Status) {
+		return struct{}{}, fn(ctx)
+	}
+
+	r := newRoutineOpts(opts, fn1)
+	r.Start()
+	return r
+}
+
 // Stopped
 
 // Stopped returns a routine which has stopped with the given result and status.
@@ -139,8 +200,10 @@ func StoppedVoid(st status.Status) RoutineVoid {
 var _ Routine[any] = (*routine1[any])(nil)
 
 type routine1[T any] struct {
-	ctx CancelContext
-	fn  Func[T]
+	ctx  CancelContext
+	fn   Func[T]
+	info routineInfo
+	rctx routineContext // passed to fn
 
 	mu       sync.Mutex
 	promise  Promise[T]
@@ -151,14 +214,22 @@ type routine1[T any] struct {
 }
 
 func newRoutine[T any](fn Func[T]) *routine1[T] {
+	return newRoutineOpts(RoutineOptions{}, fn)
+}
+
+func newRoutineOpts[T any](opts RoutineOptions, fn Func[T]) *routine1[T] {
 	ctx := NewContext()
-	promise := newPromise[T]()
+	if opts.Parent != nil {
+		ctx = NextContext(opts.Parent)
+	}
 
-	return &routine1[T]{
+	r := &routine1[T]{
 		ctx:     ctx,
 		fn:      fn,
-		promise: promise,
+		promise: newPromise[T](),
 	}
+	r.info.init(opts)
+	return r
 }
 
 // Future
@@ -187,15 +258,9 @@ func (r *routine1[T]) Status() status.Status {
 
 // Start start the routine, if not started or stopped yet.
 func (r *routine1[T]) Start() {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	if r.start || r.stop {
-		return
+	if r.tryStart() {
+		go r.run()
 	}
-
-	r.start = true
-	go r.run()
 }
 
 // Stop requests the routine to stop and returns a wait channel.
@@ -212,12 +277,14 @@ func (r *routine1[T]) Stop() <-chan struct{} {
 	// Reject if not started
 	if !r.start {
 		r.promise.Reject(status.Cancelled)
+		r.ctx.Free()
 		return r.promise.Wait()
 	}
 
 	// Cancel context and return wait
 	r.ctx.Cancel()
 	r.stop = true
+	r.info.stopping.Store(true)
 	return r.promise.Wait()
 }
 
@@ -250,6 +317,21 @@ func (r *routine1[T]) OnStop(fn func(Routine[T])) bool {
 
 // private
 
+// tryStart marks the routine as started and registers it,
+// returns false if started, stopped or rejected already.
+func (r *routine1[T]) tryStart() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.start || r.stop || r.promise.Done() {
+		return false
+	}
+
+	r.start = true
+	routines.add(&r.info)
+	return true
+}
+
 func (r *routine1[T]) run() {
 	defer r.ctx.Free()
 	defer func() {
@@ -259,7 +341,18 @@ func (r *routine1[T]) run() {
 		}
 	}()
 
-	result, st := r.fn(r.ctx)
+	// Set labels, reset them when run by an executor worker
+	if r.info.setLabels() {
+		defer r.info.resetLabels()
+	}
+
+	// Pass routine info to child routines
+	r.rctx = routineContext{
+		CancelContext: r.ctx,
+		info:          &r.info,
+	}
+
+	result, st := r.fn(&r.rctx)
 	r.complete(result, st)
 }
 
@@ -272,12 +365,22 @@ func (r *routine1[T]) complete(result T, st status.Status) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	// Unregister routine before waiters are notified
+	if r.start {
+		routines.remove(&r.info)
+	}
+
 	// Complete promise
 	ok := r.promise.Complete(result, st)
 	if !ok {
 		return
 	}
 
+	// Free context if not started, i.e. rejected
+	if !r.start {
+		r.ctx.Free()
+	}
+
 	// Notify callback
 	if r.callback != nil {
 		r.callback(r)
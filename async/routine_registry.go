@@ -0,0 +1,276 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"cmp"
+	context_ "context"
+	"fmt"
+	"io"
+	"maps"
+	"runtime/pprof"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RoutineInfo describes a running routine.
+type RoutineInfo struct {
+	// ID is a process-wide unique routine id.
+	ID uint64
+
+	// Name is the routine name, or empty.
+	Name string
+
+	// Labels are the routine labels, or nil.
+	Labels map[string]string
+
+	// Parent is the parent routine id, or zero.
+	//
+	// The parent is taken from the context the routine is started with, i.e. the routine
+	// records a parent when started with a context of another routine or its child context.
+	// Routines started without a context, i.e. via [Run] or [Execute], have no parent.
+	Parent uint64
+
+	// Started is the routine start time.
+	Started time.Time
+
+	// State is the routine state.
+	State RoutineState
+}
+
+// RoutineState is a running routine state.
+type RoutineState int
+
+const (
+	// RoutineRunning indicates that the routine is running.
+	RoutineRunning RoutineState = iota
+
+	// RoutineStopping indicates that the routine has been requested to stop.
+	RoutineStopping
+)
+
+// String returns a state string.
+func (s RoutineState) String() string {
+	switch s {
+	case RoutineRunning:
+		return "running"
+	case RoutineStopping:
+		return "stopping"
+	}
+	return fmt.Sprintf("RoutineState(%d)", int(s))
+}
+
+// Routines returns the running routines ordered by id.
+//
+// A routine is registered when it starts, including routines started by an executor,
+// and is unregistered when it completes, before its waiters are notified.
+func Routines() []RoutineInfo {
+	return routines.list(nil)
+}
+
+// DumpRoutines writes a text report of the running routines, one routine per line.
+//
+// Example:
+//
+//	2 routines
+//	#12 server running 1m30s
+//	#15 worker stopping 2.5s parent=#12 shard=1
+func DumpRoutines(w io.Writer) error {
+	list := routines.list(nil)
+	return writeRoutines(w, list)
+}
+
+// internal
+
+var (
+	routines      = newRoutineRegistry()
+	routineIDs    atomic.Uint64
+	routineScopes atomic.Uint64
+)
+
+// writeRoutines writes a text report of routines.
+func writeRoutines(w io.Writer, list []RoutineInfo) error {
+	now := time.Now()
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "%d routines\n", len(list))
+
+	for _, info := range list {
+		name := info.Name
+		if name == "" {
+			name = "-"
+		}
+		age := now.Sub(info.Started).Round(time.Millisecond)
+		fmt.Fprintf(b, "#%d %v %v %v", info.ID, name, info.State, age)
+
+		if info.Parent != 0 {
+			fmt.Fprintf(b, " parent=#%d", info.Parent)
+		}
+		for _, key := range slices.Sorted(maps.Keys(info.Labels)) {
+			fmt.Fprintf(b, " %v=%v", key, info.Labels[key])
+		}
+		b.WriteByte('\n')
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// registry
+
+// routineShards is the number of registry shards, routines are sharded by id
+// to avoid contention on start and stop.
+const routineShards = 64
+
+type routineRegistry struct {
+	shards [routineShards]routineShard
+}
+
+type routineShard struct {
+	mu      sync.Mutex
+	entries map[uint64]*routineInfo
+}
+
+func newRoutineRegistry() *routineRegistry {
+	r := &routineRegistry{}
+	for i := range r.shards {
+		r.shards[i].entries = make(map[uint64]*routineInfo)
+	}
+	return r
+}
+
+// add registers a starting routine, and sets its start time.
+func (r *routineRegistry) add(info *routineInfo) {
+	s := r.shard(info.id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info.started = time.Now()
+	s.entries[info.id] = info
+}
+
+func (r *routineRegistry) remove(info *routineInfo) {
+	s := r.shard(info.id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, info.id)
+}
+
+// list returns the running routines ordered by id, the filter is optional.
+func (r *routineRegistry) list(filter func(*routineInfo) bool) []RoutineInfo {
+	var result []RoutineInfo
+	for i := range r.shards {
+		s := &r.shards[i]
+		s.mu.Lock()
+		for _, info := range s.entries {
+			if filter == nil || filter(info) {
+				result = append(result, info.snapshot())
+			}
+		}
+		s.mu.Unlock()
+	}
+
+	slices.SortFunc(result, func(a, b RoutineInfo) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return result
+}
+
+func (r *routineRegistry) shard(id uint64) *routineShard {
+	return &r.shards[id%routineShards]
+}
+
+// info
+
+type routineInfo struct {
+	id     uint64
+	name   string
+	labels map[string]string
+	parent uint64
+	scope  uint64 // inherited from the parent, used to track test routines
+
+	started  time.Time // set by the registry
+	stopping atomic.Bool
+}
+
+func (i *routineInfo) init(opts RoutineOptions) {
+	i.id = routineIDs.Add(1)
+	i.name = opts.Name
+	i.labels = opts.Labels
+
+	if opts.Parent != nil {
+		parent, ok := opts.Parent.Value(routineKey{}).(*routineInfo)
+		if ok {
+			i.parent = parent.id
+			i.scope = parent.scope
+		}
+	}
+}
+
+// setLabels sets the routine pprof labels on the current goroutine, returns false if none.
+func (i *routineInfo) setLabels() bool {
+	if i.name == "" && len(i.labels) == 0 {
+		return false
+	}
+
+	args := make([]string, 0, 2+2*len(i.labels))
+	if i.name != "" {
+		args = append(args, "routine", i.name)
+	}
+	for key, value := range i.labels {
+		args = append(args, key, value)
+	}
+
+	ctx := pprof.WithLabels(context_.Background(), pprof.Labels(args...))
+	pprof.SetGoroutineLabels(ctx)
+	return true
+}
+
+// resetLabels clears the pprof labels on the current goroutine, i.e. on an executor worker.
+func (i *routineInfo) resetLabels() {
+	pprof.SetGoroutineLabels(context_.Background())
+}
+
+// snapshot returns a routine info, must be called under the registry shard lock.
+func (i *routineInfo) snapshot() RoutineInfo {
+	state := RoutineRunning
+	if i.stopping.Load() {
+		state = RoutineStopping
+	}
+
+	return RoutineInfo{
+		ID:      i.id,
+		Name:    i.name,
+		Labels:  maps.Clone(i.labels),
+		Parent:  i.parent,
+		Started: i.started,
+		State:   state,
+	}
+}
+
+// context
+
+var _ CancelContext = (*routineContext)(nil)
+
+type routineKey struct{}
+
+// routineContext is a routine context which returns the routine info for the routine key,
+// so that child routines can record their parent.
+type routineContext struct {
+	CancelContext
+	info *routineInfo
+}
+
+// Value returns a context value for a key, or nil.
+func (x *routineContext) Value(key any) any {
+	if key == (routineKey{}) {
+		return x.info
+	}
+	return x.CancelContext.Value(key)
+}
@@ -0,0 +1,294 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"bytes"
+	"fmt"
+	"runtime/pprof"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRoutineInfo(t *testing.T, id uint64) RoutineInfo {
+	for _, info := range Routines() {
+		if info.ID == id {
+			return info
+		}
+	}
+
+	t.Fatalf("routine #%d not found", id)
+	return RoutineInfo{}
+}
+
+func testRoutineID[T any](r Routine[T]) uint64 {
+	return r.(*routine1[T]).info.id
+}
+
+// Routines
+
+func TestRoutines__should_return_running_routine(t *testing.T) {
+	opts := RoutineOptions{
+		Name:   "test",
+		Labels: map[string]string{"key": "value"},
+	}
+	r := RunVoidOpts(opts, func(ctx Context) status.Status {
+		<-ctx.Wait()
+		return ctx.Status()
+	})
+	defer StopWait(r)
+
+	info := testRoutineInfo(t, testRoutineID(r))
+	assert.Equal(t, "test", info.Name)
+	assert.Equal(t, map[string]string{"key": "value"}, info.Labels)
+	assert.Equal(t, RoutineRunning, info.State)
+	assert.False(t, info.Started.IsZero())
+}
+
+func TestRoutines__should_record_parent_routine(t *testing.T) {
+	children := make(chan RoutineVoid, 1)
+
+	parent := RunVoid(func(ctx Context) status.Status {
+		children <- RunVoidContext(ctx, func(ctx Context) status.Status {
+			<-ctx.Wait()
+			return ctx.Status()
+		})
+
+		<-ctx.Wait()
+		return ctx.Status()
+	})
+	defer StopWait(parent)
+
+	child := <-children
+	defer StopWait(child)
+
+	info := testRoutineInfo(t, testRoutineID(child))
+	assert.Equal(t, testRoutineID(parent), info.Parent)
+}
+
+func TestRoutines__should_record_parent_from_child_context(t *testing.T) {
+	type key struct{}
+	children := make(chan RoutineVoid, 1)
+
+	parent := RunVoid(func(ctx Context) status.Status {
+		ctx1 := WithValue(ctx, key{}, "value")
+		children <- RunVoidContext(ctx1, func(ctx Context) status.Status {
+			<-ctx.Wait()
+			return ctx.Status()
+		})
+
+		<-ctx.Wait()
+		return ctx.Status()
+	})
+	defer StopWait(parent)
+
+	child := <-children
+	defer StopWait(child)
+
+	info := testRoutineInfo(t, testRoutineID(child))
+	assert.Equal(t, testRoutineID(parent), info.Parent)
+}
+
+func TestRoutines__should_return_executor_routines(t *testing.T) {
+	e := NewExecutor(ExecutorOptions{})
+	defer e.Stop()
+
+	started := make(chan struct{})
+	r := ExecuteVoid(e, func(ctx Context) status.Status {
+		close(started)
+		<-ctx.Wait()
+		return ctx.Status()
+	})
+	defer StopWait(r)
+	<-started
+
+	info := testRoutineInfo(t, testRoutineID(r))
+	assert.Equal(t, RoutineRunning, info.State)
+}
+
+func TestRoutines__should_return_routines_ordered_by_id(t *testing.T) {
+	var list []RoutineVoid
+	for range routineShards + 1 {
+		r := RunVoid(func(ctx Context) status.Status {
+			<-ctx.Wait()
+			return ctx.Status()
+		})
+		list = append(list, r)
+	}
+	defer StopWaitAll(list...)
+
+	var ids []uint64
+	for _, info := range Routines() {
+		ids = append(ids, info.ID)
+	}
+
+	assert.True(t, slices.IsSorted(ids))
+	for _, r := range list {
+		assert.Contains(t, ids, testRoutineID(r))
+	}
+}
+
+func TestRoutines__should_return_stopping_state(t *testing.T) {
+	stop := make(chan struct{})
+	r := RunVoid(func(ctx Context) status.Status {
+		<-stop
+		return status.OK
+	})
+	defer func() {
+		close(stop)
+		<-r.Wait()
+	}()
+
+	r.Stop()
+
+	info := testRoutineInfo(t, testRoutineID(r))
+	assert.Equal(t, RoutineStopping, info.State)
+}
+
+func TestRoutines__should_unregister_completed_routine(t *testing.T) {
+	r := RunVoid(func(ctx Context) status.Status {
+		return status.OK
+	})
+	<-r.Wait()
+
+	id := testRoutineID(r)
+	for _, info := range Routines() {
+		assert.NotEqual(t, id, info.ID)
+	}
+}
+
+func TestRoutine__should_set_pprof_labels(t *testing.T) {
+	r := RunVoidOpts(RoutineOptions{Name: "labeled"}, func(ctx Context) status.Status {
+		<-ctx.Wait()
+		return ctx.Status()
+	})
+	defer StopWait(r)
+
+	require.Eventually(t, func() bool {
+		buf := &bytes.Buffer{}
+		pprof.Lookup("goroutine").WriteTo(buf, 1)
+		return strings.Contains(buf.String(), `"routine":"labeled"`)
+	}, time.Second, 10*time.Millisecond)
+}
+
+// DumpRoutines
+
+func TestDumpRoutines__should_write_routines(t *testing.T) {
+	opts := RoutineOptions{
+		Name:   "dumped",
+		Labels: map[string]string{"shard": "1"},
+	}
+	r := RunVoidOpts(opts, func(ctx Context) status.Status {
+		<-ctx.Wait()
+		return ctx.Status()
+	})
+	defer StopWait(r)
+
+	buf := &bytes.Buffer{}
+	err := DumpRoutines(buf)
+	require.NoError(t, err)
+
+	prefix := fmt.Sprintf("#%d dumped running ", testRoutineID(r))
+	assert.Contains(t, buf.String(), prefix)
+	assert.Contains(t, buf.String(), " shard=1\n")
+}
+
+// TestRoutineLeaks
+
+type testLeakT struct {
+	*testing.T
+	cleanup func()
+	errors  []string
+}
+
+func (t *testLeakT) Cleanup(fn func()) {
+	t.cleanup = fn
+}
+
+func (t *testLeakT) Error(args ...any) {
+	t.errors = append(t.errors, fmt.Sprint(args...))
+}
+
+func TestTestRoutineLeaks__should_fail_test_with_running_routines(t *testing.T) {
+	timeout := TestRoutineLeakTimeout
+	TestRoutineLeakTimeout = 10 * time.Millisecond
+	defer func() { TestRoutineLeakTimeout = timeout }()
+
+	t1 := &testLeakT{T: t}
+	ctx := TestRoutineLeaks(t1)
+
+	opts := RoutineOptions{Name: "leaked", Parent: ctx}
+	r := RunVoidOpts(opts, func(ctx Context) status.Status {
+		<-ctx.Wait()
+		return ctx.Status()
+	})
+	defer StopWait(r)
+
+	t1.cleanup()
+	require.Len(t, t1.errors, 1)
+	assert.Contains(t, t1.errors[0], "leaked running")
+}
+
+func TestTestRoutineLeaks__should_track_child_routines(t *testing.T) {
+	timeout := TestRoutineLeakTimeout
+	TestRoutineLeakTimeout = 10 * time.Millisecond
+	defer func() { TestRoutineLeakTimeout = timeout }()
+
+	t1 := &testLeakT{T: t}
+	ctx := TestRoutineLeaks(t1)
+
+	started := make(chan struct{})
+	parent := RunVoidContext(ctx, func(ctx Context) status.Status {
+		opts := RoutineOptions{Name: "child", Parent: ctx}
+		child := RunVoidOpts(opts, func(ctx Context) status.Status {
+			<-ctx.Wait()
+			return ctx.Status()
+		})
+		close(started)
+
+		<-ctx.Wait()
+		<-child.Wait()
+		return ctx.Status()
+	})
+	defer StopWait(parent)
+	<-started
+
+	t1.cleanup()
+	require.Len(t, t1.errors, 1)
+	assert.Contains(t, t1.errors[0], "child running")
+}
+
+func TestTestRoutineLeaks__should_ignore_routines_started_without_context(t *testing.T) {
+	t1 := &testLeakT{T: t}
+	TestRoutineLeaks(t1)
+
+	r := RunVoid(func(ctx Context) status.Status {
+		<-ctx.Wait()
+		return ctx.Status()
+	})
+	defer StopWait(r)
+
+	t1.cleanup()
+	assert.Empty(t, t1.errors)
+}
+
+func TestTestRoutineLeaks__should_pass_when_routines_stopped(t *testing.T) {
+	t1 := &testLeakT{T: t}
+	ctx := TestRoutineLeaks(t1)
+
+	r := RunVoidContext(ctx, func(ctx Context) status.Status {
+		return status.OK
+	})
+	<-r.Wait()
+
+	t1.cleanup()
+	assert.Empty(t, t1.errors)
+}
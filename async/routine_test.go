@@ -218,3 +218,31 @@ func TestRoutine__should_call_stop_callbacks_on_stop(t *testing.T) {
 		t.Fatal("timeout")
 	}
 }
+
+// RunContext
+
+func TestRunContext__should_inherit_parent_values_and_cancellation(t *testing.T) {
+	type key struct{}
+
+	parent := NewContext()
+	defer parent.Free()
+
+	ctx := WithValue(parent, key{}, "value")
+	r := RunVoidContext(ctx, func(ctx Context) status.Status {
+		v, ok := Value[string](ctx, key{})
+		if !ok || v != "value" {
+			return status.Error("value not found")
+		}
+
+		<-ctx.Wait()
+		return ctx.Status()
+	})
+
+	parent.Cancel()
+	select {
+	case <-r.Wait():
+	case <-time.After(time.Second):
+		t.Fatal("routine was not cancelled")
+	}
+	assert.Equal(t, status.Cancelled, r.Status())
+}
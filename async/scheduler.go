@@ -0,0 +1,454 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// Scheduler runs jobs on fixed intervals or cron schedules, each job run is a routine.
+//
+// The scheduler runs jobs while its Run method is running, the job routines run with child
+// contexts of the run context, and are cancelled with it. Jobs can be added before or while
+// the scheduler is running.
+//
+// Example:
+//
+//	s := async.NewScheduler(async.SchedulerOptions{})
+//	s.Add("flush", async.IntervalSchedule(time.Second, 100*time.Millisecond), async.OverlapSkip, flush)
+//
+//	cron, st := async.ParseCron("0 3 * * *")
+//	if !st.OK() {
+//		return st
+//	}
+//	s.Add("compact", cron, async.OverlapQueue, compact)
+//
+//	r := async.RunVoid(s.Run)
+//	defer async.StopWait(r)
+type Scheduler interface {
+	// Add adds a job, and schedules it if the scheduler is running.
+	Add(name string, schedule Schedule, overlap OverlapPolicy, fn FuncVoid) ScheduledJob
+
+	// Jobs returns the jobs in the order they were added.
+	Jobs() []ScheduledJob
+
+	// Run runs the scheduler until the context is cancelled, stops and awaits the running jobs.
+	// The method returns an error if the scheduler is already running.
+	Run(ctx Context) status.Status
+}
+
+// ScheduledJob is a job added to a scheduler.
+type ScheduledJob interface {
+	// Name returns the job name.
+	Name() string
+
+	// Running indicates that the job routine is running.
+	Running() Flag
+
+	// LastRun returns the last run start time, or zero if not run yet.
+	LastRun() time.Time
+
+	// LastStatus returns the last run status, or none if not run yet.
+	LastStatus() status.Status
+
+	// NextRun returns the next run time, or zero if not scheduled.
+	NextRun() time.Time
+}
+
+// Schedule returns job run times.
+type Schedule interface {
+	// Next returns the next run time after t, or zero if there are no more runs.
+	Next(t time.Time) time.Time
+}
+
+// SchedulerOptions specifies the scheduler options.
+type SchedulerOptions struct {
+	// Clock is the scheduler clock, nil means the system clock.
+	Clock Clock
+}
+
+// OverlapPolicy specifies how a scheduler handles a job run when the previous run is running.
+type OverlapPolicy int
+
+const (
+	// OverlapSkip skips the run.
+	OverlapSkip OverlapPolicy = iota
+
+	// OverlapQueue queues the run, and starts it when the previous one completes.
+	OverlapQueue
+
+	// OverlapCancel stops the previous run, and starts a new one when it completes.
+	OverlapCancel
+)
+
+// NewScheduler returns a new scheduler.
+func NewScheduler(opts SchedulerOptions) Scheduler {
+	return newScheduler(opts)
+}
+
+// IntervalSchedule returns a schedule which runs a job every interval plus a random jitter
+// in [0, jitter). Missed runs are skipped.
+//
+// The scheduler adds the jitter to the unjittered run times, so that it does not accumulate
+// and the runs do not drift.
+func IntervalSchedule(interval time.Duration, jitter time.Duration) Schedule {
+	if interval <= 0 {
+		panic("schedule interval must be positive")
+	}
+	return intervalSchedule{interval: interval, jitter: jitter}
+}
+
+// internal
+
+var _ Scheduler = (*scheduler)(nil)
+
+type scheduler struct {
+	clock Clock
+	wake  chan struct{}
+
+	mu      sync.Mutex
+	jobs    []*scheduledJob
+	ctx     Context // run context, nil when not running
+	running bool
+}
+
+func newScheduler(opts SchedulerOptions) *scheduler {
+	if opts.Clock == nil {
+		opts.Clock = SystemClock()
+	}
+
+	return &scheduler{
+		clock: opts.Clock,
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+// Add adds a job, and schedules it if the scheduler is running.
+func (s *scheduler) Add(name string, schedule Schedule, overlap OverlapPolicy,
+	fn FuncVoid) ScheduledJob {
+	j := newScheduledJob(s.clock, name, schedule, overlap, fn)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs = append(s.jobs, j)
+	if s.running {
+		j.schedule(s.ctx, s.clock.Now())
+		s.notify()
+	}
+	return j
+}
+
+// Jobs returns the jobs in the order they were added.
+func (s *scheduler) Jobs() []ScheduledJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]ScheduledJob, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		result = append(result, j)
+	}
+	return result
+}
+
+// Run runs the scheduler until the context is cancelled, stops and awaits the running jobs.
+// The method returns an error if the scheduler is already running.
+func (s *scheduler) Run(ctx Context) status.Status {
+	if st := s.start(ctx); !st.OK() {
+		return st
+	}
+	defer s.stop()
+
+	for {
+		next := s.runDue()
+
+		// Await next run
+		var timer ClockTimer
+		var timerC <-chan time.Time
+		if !next.IsZero() {
+			timer = s.clock.NewTimer(next.Sub(s.clock.Now()))
+			timerC = timer.C()
+		}
+
+		select {
+		case <-ctx.Wait():
+		case <-timerC:
+		case <-s.wake:
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Done() {
+			return ctx.Status()
+		}
+	}
+}
+
+// private
+
+func (s *scheduler) start(ctx Context) status.Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return status.Errorf("scheduler is already running")
+	}
+	s.ctx = ctx
+	s.running = true
+
+	now := s.clock.Now()
+	for _, j := range s.jobs {
+		j.schedule(ctx, now)
+	}
+	return status.OK
+}
+
+// stop unschedules jobs, stops and awaits their routines.
+func (s *scheduler) stop() {
+	s.mu.Lock()
+	jobs := s.jobs
+	s.ctx = nil
+	s.running = false
+	s.mu.Unlock()
+
+	for _, j := range jobs {
+		j.stop()
+	}
+}
+
+// runDue triggers the due jobs, returns the earliest next run time, or zero.
+func (s *scheduler) runDue() time.Time {
+	s.mu.Lock()
+	jobs := s.jobs
+	s.mu.Unlock()
+
+	now := s.clock.Now()
+	var earliest time.Time
+
+	for _, j := range jobs {
+		next := j.triggerDue(now)
+		if next.IsZero() {
+			continue
+		}
+		if earliest.IsZero() || next.Before(earliest) {
+			earliest = next
+		}
+	}
+	return earliest
+}
+
+func (s *scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// job
+
+var _ ScheduledJob = (*scheduledJob)(nil)
+
+type scheduledJob struct {
+	clock    Clock
+	name     string
+	sched    Schedule
+	overlap  OverlapPolicy
+	fn       FuncVoid
+	runningF MutFlag
+
+	mu       sync.Mutex
+	ctx      Context   // scheduler run context, parent of job routines
+	base     time.Time // next run time without jitter
+	next     time.Time
+	lastRun  time.Time
+	lastSt   status.Status
+	routine  RoutineVoid // running routine or nil
+	pending  int         // queued runs
+	disabled bool        // scheduler stopped
+}
+
+func newScheduledJob(clock Clock, name string, sched Schedule, overlap OverlapPolicy,
+	fn FuncVoid) *scheduledJob {
+	return &scheduledJob{
+		clock:    clock,
+		name:     name,
+		sched:    sched,
+		overlap:  overlap,
+		fn:       fn,
+		runningF: UnsetFlag(),
+		lastSt:   status.None,
+		disabled: true,
+	}
+}
+
+// Name returns the job name.
+func (j *scheduledJob) Name() string {
+	return j.name
+}
+
+// Running indicates that the job routine is running.
+func (j *scheduledJob) Running() Flag {
+	return j.runningF
+}
+
+// LastRun returns the last run start time, or zero if not run yet.
+func (j *scheduledJob) LastRun() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.lastRun
+}
+
+// LastStatus returns the last run status, or none if not run yet.
+func (j *scheduledJob) LastStatus() status.Status {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.lastSt
+}
+
+// NextRun returns the next run time, or zero if not scheduled.
+func (j *scheduledJob) NextRun() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.next
+}
+
+// private
+
+// schedule enables the job and computes the next run time.
+func (j *scheduledJob) schedule(ctx Context, now time.Time) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.ctx = ctx
+	j.disabled = false
+	j.setNext(now)
+}
+
+// triggerDue runs the job if it is due, and returns the next run time.
+func (j *scheduledJob) triggerDue(now time.Time) time.Time {
+	j.mu.Lock()
+	if j.next.IsZero() || now.Before(j.next) {
+		next := j.next
+		j.mu.Unlock()
+		return next
+	}
+
+	// Schedule next run from the base time, skip missed runs
+	j.setNext(j.base)
+	if !j.base.IsZero() && !j.base.After(now) {
+		j.setNext(now)
+	}
+	next := j.next
+
+	// Start run or handle overlap
+	var cancel RoutineVoid
+	switch {
+	case j.routine == nil:
+		j.start()
+	case j.overlap == OverlapQueue:
+		j.pending++
+	case j.overlap == OverlapCancel:
+		j.pending = 1
+		cancel = j.routine
+	}
+	j.mu.Unlock()
+
+	// Stop outside of lock, the routine callback locks the job
+	if cancel != nil {
+		cancel.Stop()
+	}
+	return next
+}
+
+// stop disables the job, stops and awaits its routine.
+func (j *scheduledJob) stop() {
+	j.mu.Lock()
+	r := j.routine
+	j.ctx = nil
+	j.base = time.Time{}
+	j.next = time.Time{}
+	j.pending = 0
+	j.disabled = true
+	j.mu.Unlock()
+
+	if r != nil {
+		<-r.Stop()
+	}
+}
+
+// setNext computes the next run time after t, must be called under the lock.
+// The jitter is added to the base time, so that it does not accumulate.
+func (j *scheduledJob) setNext(t time.Time) {
+	s, ok := j.sched.(intervalSchedule)
+	if !ok {
+		j.base = j.sched.Next(t)
+		j.next = j.base
+		return
+	}
+
+	j.base = t.Add(s.interval)
+	j.next = s.addJitter(j.base)
+}
+
+// start starts a new routine with a child context of the run context,
+// must be called under the lock.
+func (j *scheduledJob) start() {
+	opts := RoutineOptions{Parent: j.ctx}
+	r := NewRoutineVoidOpts(opts, j.fn)
+	r.OnStop(j.onStop)
+
+	j.routine = r
+	j.lastRun = j.clock.Now()
+	j.runningF.Set()
+
+	r.Start()
+}
+
+// onStop records the run status, and starts a pending run.
+func (j *scheduledJob) onStop(r RoutineVoid) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.lastSt = r.Status()
+	j.routine = nil
+
+	if j.pending > 0 && !j.disabled {
+		j.pending--
+		j.start()
+		return
+	}
+	j.runningF.Unset()
+}
+
+// interval
+
+var _ Schedule = intervalSchedule{}
+
+type intervalSchedule struct {
+	interval time.Duration
+	jitter   time.Duration
+}
+
+// Next returns the next run time after t.
+func (s intervalSchedule) Next(t time.Time) time.Time {
+	next := t.Add(s.interval)
+	return s.addJitter(next)
+}
+
+// addJitter adds a random jitter to a run time.
+func (s intervalSchedule) addJitter(t time.Time) time.Time {
+	if s.jitter <= 0 {
+		return t
+	}
+	return t.Add(rand.N(s.jitter))
+}
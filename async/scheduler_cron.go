@@ -0,0 +1,194 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// ParseCron parses a standard five-field cron expression, and returns a schedule.
+//
+// Fields are minute (0-59), hour (0-23), day of month (1-31), month (1-12), and day of week
+// (0-6, 0 or 7 is Sunday). Each field supports "*", values, ranges "a-b", lists "a,b", and
+// steps "*/n" or "a-b/n". When both day fields are restricted, a time matches either of them.
+//
+// The macros @yearly, @monthly, @weekly, @daily and @hourly are also supported.
+// Times are computed in the location of the time passed to Next.
+func ParseCron(expr string) (Schedule, status.Status) {
+	return parseCron(expr)
+}
+
+// internal
+
+var _ Schedule = (*cronSchedule)(nil)
+
+// cronSearchYears limits the next time search for expressions which never match, i.e. Feb 30.
+const cronSearchYears = 5
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type cronSchedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	domAny bool // day of month is "*"
+	dowAny bool // day of week is "*"
+}
+
+func parseCron(expr string) (*cronSchedule, status.Status) {
+	expr = strings.TrimSpace(expr)
+	if m, ok := cronMacros[expr]; ok {
+		expr = m
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, status.ParseErrorf("cron expression must have 5 fields, got %d: %q",
+			len(fields), expr)
+	}
+
+	var st status.Status
+	c := &cronSchedule{
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}
+
+	if c.minute, st = parseCronField(fields[0], 0, 59); !st.OK() {
+		return nil, st.WrapText("cron minute")
+	}
+	if c.hour, st = parseCronField(fields[1], 0, 23); !st.OK() {
+		return nil, st.WrapText("cron hour")
+	}
+	if c.dom, st = parseCronField(fields[2], 1, 31); !st.OK() {
+		return nil, st.WrapText("cron day of month")
+	}
+	if c.month, st = parseCronField(fields[3], 1, 12); !st.OK() {
+		return nil, st.WrapText("cron month")
+	}
+	if c.dow, st = parseCronField(fields[4], 0, 7); !st.OK() {
+		return nil, st.WrapText("cron day of week")
+	}
+
+	// Sunday is 0 or 7
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	return c, status.OK
+}
+
+// Next returns the next minute after t which matches the expression, or zero if none.
+func (c *cronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	end := t.AddDate(cronSearchYears, 0, 0)
+
+	for t.Before(end) {
+		if !cronHas(c.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !cronHas(c.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !cronHas(c.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// private
+
+func (c *cronSchedule) matchDay(t time.Time) bool {
+	dom := cronHas(c.dom, t.Day())
+	dow := cronHas(c.dow, int(t.Weekday()))
+
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	}
+	return dom || dow
+}
+
+func cronHas(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
+
+// parseCronField parses a comma-separated list of ranges with optional steps into a bitset.
+func parseCronField(field string, min, max int) (uint64, status.Status) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+
+		// Parse step
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return 0, status.ParseErrorf("invalid step %q", part)
+			}
+			step = n
+		}
+
+		// Parse range
+		lo, hi := min, max
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			loStr, hiStr, _ := strings.Cut(rng, "-")
+			var err0, err1 error
+			lo, err0 = strconv.Atoi(loStr)
+			hi, err1 = strconv.Atoi(hiStr)
+			if err0 != nil || err1 != nil {
+				return 0, status.ParseErrorf("invalid range %q", part)
+			}
+		default:
+			n, err := strconv.Atoi(rng)
+			if err != nil {
+				return 0, status.ParseErrorf("invalid value %q", part)
+			}
+			lo = n
+			if !hasStep {
+				hi = n
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return 0, status.ParseErrorf("value out of range %d-%d: %q", min, max, part)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, status.OK
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCronNext(t *testing.T, expr string, from string) string {
+	t.Helper()
+
+	s, st := ParseCron(expr)
+	require.True(t, st.OK(), st.String())
+
+	t0, err := time.Parse("2006-01-02 15:04", from)
+	require.NoError(t, err)
+
+	next := s.Next(t0)
+	if next.IsZero() {
+		return ""
+	}
+	return next.Format("2006-01-02 15:04 Mon")
+}
+
+// ParseCron
+
+func TestParseCron__should_compute_next_time(t *testing.T) {
+	assert.Equal(t, "2026-01-01 00:01 Thu", testCronNext(t, "* * * * *", "2026-01-01 00:00"))
+	assert.Equal(t, "2026-01-01 00:15 Thu", testCronNext(t, "*/15 * * * *", "2026-01-01 00:00"))
+	assert.Equal(t, "2026-01-01 03:00 Thu", testCronNext(t, "0 3 * * *", "2026-01-01 00:00"))
+	assert.Equal(t, "2026-01-02 03:00 Fri", testCronNext(t, "0 3 * * *", "2026-01-01 03:00"))
+	assert.Equal(t, "2026-02-01 00:00 Sun", testCronNext(t, "@monthly", "2026-01-15 12:00"))
+	assert.Equal(t, "2027-01-01 00:00 Fri", testCronNext(t, "@yearly", "2026-01-01 00:00"))
+}
+
+func TestParseCron__should_match_weekday_ranges(t *testing.T) {
+	// 2026-01-03 is Saturday
+	assert.Equal(t, "2026-01-05 09:00 Mon", testCronNext(t, "0 9-17 * * 1-5", "2026-01-03 10:00"))
+	assert.Equal(t, "2026-01-05 10:30 Mon", testCronNext(t, "30 9-17/1 * * 1-5", "2026-01-05 10:00"))
+	assert.Equal(t, "2026-01-04 00:00 Sun", testCronNext(t, "0 0 * * 7", "2026-01-01 00:00"))
+}
+
+func TestParseCron__should_match_either_day_when_both_restricted(t *testing.T) {
+	// 15th of month or Monday, 2026-01-05 is Monday
+	assert.Equal(t, "2026-01-05 00:00 Mon", testCronNext(t, "0 0 15 * 1", "2026-01-01 00:00"))
+	assert.Equal(t, "2026-01-15 00:00 Thu", testCronNext(t, "0 0 15 * 1", "2026-01-12 00:00"))
+}
+
+func TestParseCron__should_return_zero_when_never_matches(t *testing.T) {
+	assert.Equal(t, "", testCronNext(t, "0 0 30 2 *", "2026-01-01 00:00"))
+}
+
+func TestParseCron__should_return_parse_error_on_invalid_expression(t *testing.T) {
+	exprs := []string{
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"*/0 * * * *",
+		"a * * * *",
+		"5-1 * * * *",
+	}
+
+	for _, expr := range exprs {
+		_, st := ParseCron(expr)
+		assert.Equal(t, status.CodeParseError, st.Code, expr)
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testClock is a manual clock, timers fire on Advance.
+type testClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*testClockTimer
+}
+
+type testClockTimer struct {
+	clock *testClock
+	at    time.Time
+	c     chan time.Time
+}
+
+func newTestClock() *testClock {
+	return &testClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *testClock) NewTimer(d time.Duration) ClockTimer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &testClockTimer{clock: c, at: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- c.now
+		return t
+	}
+
+	c.timers = append(c.timers, t)
+	return t
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	timers := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			timers = append(timers, t)
+			continue
+		}
+		t.c <- c.now
+	}
+	c.timers = timers
+}
+
+func (t *testClockTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *testClockTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, t1 := range c.timers {
+		if t1 == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func testRunScheduler(t *testing.T, s Scheduler) RoutineVoid {
+	r := RunVoid(s.Run)
+	t.Cleanup(func() { <-r.Stop() })
+	return r
+}
+
+func testEventually(t *testing.T, fn func() bool) {
+	t.Helper()
+	require.Eventually(t, fn, time.Second, time.Millisecond)
+}
+
+// Run
+
+func TestScheduler_Run__should_run_job_on_interval(t *testing.T) {
+	clock := newTestClock()
+	s := newScheduler(SchedulerOptions{Clock: clock})
+
+	var runs atomic.Int32
+	job := s.Add("job", IntervalSchedule(time.Second, 0), OverlapSkip, func(ctx Context) status.Status {
+		runs.Add(1)
+		return status.OK
+	})
+	testRunScheduler(t, s)
+	testEventually(t, func() bool { return !job.NextRun().IsZero() })
+
+	start := clock.Now()
+	assert.Equal(t, start.Add(time.Second), job.NextRun())
+
+	clock.Advance(time.Second)
+	testEventually(t, func() bool { return runs.Load() == 1 })
+
+	clock.Advance(time.Second)
+	testEventually(t, func() bool { return runs.Load() == 2 })
+	testEventually(t, func() bool { return job.LastStatus().OK() })
+
+	assert.Equal(t, start.Add(2*time.Second), job.LastRun())
+	assert.Equal(t, start.Add(3*time.Second), job.NextRun())
+}
+
+func TestScheduler_Run__should_return_error_when_already_running(t *testing.T) {
+	s := newScheduler(SchedulerOptions{Clock: newTestClock()})
+	testRunScheduler(t, s)
+	testEventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.running
+	})
+
+	st := s.Run(NoContext())
+	assert.Equal(t, status.CodeError, st.Code)
+}
+
+func TestScheduler_Run__should_stop_running_jobs_when_cancelled(t *testing.T) {
+	clock := newTestClock()
+	s := newScheduler(SchedulerOptions{Clock: clock})
+
+	job := s.Add("job", IntervalSchedule(time.Second, 0), OverlapSkip, func(ctx Context) status.Status {
+		<-ctx.Wait()
+		return ctx.Status()
+	})
+	r := RunVoid(s.Run)
+	testEventually(t, func() bool { return !job.NextRun().IsZero() })
+
+	clock.Advance(time.Second)
+	testEventually(t, func() bool { return job.Running().IsSet() })
+
+	<-r.Stop()
+	assert.Equal(t, status.Cancelled, r.Status())
+	assert.False(t, job.Running().IsSet())
+	assert.Equal(t, status.Cancelled, job.LastStatus())
+	assert.True(t, job.NextRun().IsZero())
+}
+
+func TestScheduler_Run__should_run_jobs_with_child_contexts(t *testing.T) {
+	type key struct{}
+	clock := newTestClock()
+	s := newScheduler(SchedulerOptions{Clock: clock})
+
+	values := make(chan string, 1)
+	job := s.Add("job", IntervalSchedule(time.Second, 0), OverlapSkip, func(ctx Context) status.Status {
+		v, _ := Value[string](ctx, key{})
+		values <- v
+
+		<-ctx.Wait()
+		return ctx.Status()
+	})
+
+	ctx := NewContext()
+	defer ctx.Free()
+
+	done := make(chan status.Status, 1)
+	go func() {
+		done <- s.Run(WithValue(ctx, key{}, "value"))
+	}()
+	testEventually(t, func() bool { return !job.NextRun().IsZero() })
+
+	clock.Advance(time.Second)
+	assert.Equal(t, "value", <-values)
+
+	ctx.Cancel()
+	assert.Equal(t, status.CodeCancelled, (<-done).Code)
+	assert.Equal(t, status.CodeCancelled, job.LastStatus().Code)
+}
+
+// Overlap
+
+func TestScheduler__should_skip_run_when_overlap_skip(t *testing.T) {
+	clock := newTestClock()
+	s := newScheduler(SchedulerOptions{Clock: clock})
+
+	var runs atomic.Int32
+	release := make(chan struct{})
+	job := s.Add("job", IntervalSchedule(time.Second, 0), OverlapSkip, func(ctx Context) status.Status {
+		runs.Add(1)
+		<-release
+		return status.OK
+	})
+	testRunScheduler(t, s)
+	testEventually(t, func() bool { return !job.NextRun().IsZero() })
+
+	clock.Advance(time.Second)
+	testEventually(t, func() bool { return runs.Load() == 1 })
+
+	next := job.NextRun()
+	clock.Advance(time.Second)
+	testEventually(t, func() bool { return job.NextRun().After(next) })
+
+	close(release)
+	testEventually(t, func() bool { return !job.Running().IsSet() })
+	assert.Equal(t, int32(1), runs.Load())
+}
+
+func TestScheduler__should_queue_runs_when_overlap_queue(t *testing.T) {
+	clock := newTestClock()
+	s := newScheduler(SchedulerOptions{Clock: clock})
+
+	var runs atomic.Int32
+	release := make(chan struct{})
+	job := s.Add("job", IntervalSchedule(time.Second, 0), OverlapQueue, func(ctx Context) status.Status {
+		if runs.Add(1) == 1 {
+			<-release
+		}
+		return status.OK
+	})
+	testRunScheduler(t, s)
+	testEventually(t, func() bool { return !job.NextRun().IsZero() })
+
+	clock.Advance(time.Second)
+	testEventually(t, func() bool { return runs.Load() == 1 })
+
+	for i := 0; i < 2; i++ {
+		next := job.NextRun()
+		clock.Advance(time.Second)
+		testEventually(t, func() bool { return job.NextRun().After(next) })
+	}
+
+	close(release)
+	testEventually(t, func() bool { return runs.Load() == 3 && !job.Running().IsSet() })
+}
+
+func TestScheduler__should_cancel_previous_run_when_overlap_cancel(t *testing.T) {
+	clock := newTestClock()
+	s := newScheduler(SchedulerOptions{Clock: clock})
+
+	var runs atomic.Int32
+	job := s.Add("job", IntervalSchedule(time.Second, 0), OverlapCancel, func(ctx Context) status.Status {
+		runs.Add(1)
+		<-ctx.Wait()
+		return ctx.Status()
+	})
+	testRunScheduler(t, s)
+	testEventually(t, func() bool { return !job.NextRun().IsZero() })
+
+	clock.Advance(time.Second)
+	testEventually(t, func() bool { return runs.Load() == 1 })
+
+	clock.Advance(time.Second)
+	testEventually(t, func() bool { return runs.Load() == 2 })
+	assert.Equal(t, status.Cancelled, job.LastStatus())
+	assert.True(t, job.Running().IsSet())
+}
+
+// Add
+
+func TestScheduler_Add__should_schedule_job_when_running(t *testing.T) {
+	clock := newTestClock()
+	s := newScheduler(SchedulerOptions{Clock: clock})
+	testRunScheduler(t, s)
+	testEventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.running
+	})
+
+	var runs atomic.Int32
+	s.Add("job", IntervalSchedule(time.Second, 0), OverlapSkip, func(ctx Context) status.Status {
+		runs.Add(1)
+		return status.OK
+	})
+
+	clock.Advance(time.Second)
+	testEventually(t, func() bool { return runs.Load() == 1 })
+}
+
+// IntervalSchedule
+
+func TestIntervalSchedule__should_add_jitter(t *testing.T) {
+	s := IntervalSchedule(time.Second, 100*time.Millisecond)
+	now := time.Now()
+
+	for i := 0; i < 100; i++ {
+		next := s.Next(now)
+		d := next.Sub(now)
+		assert.GreaterOrEqual(t, d, time.Second)
+		assert.Less(t, d, 1100*time.Millisecond)
+	}
+}
+
+func TestScheduler__should_not_accumulate_interval_jitter(t *testing.T) {
+	clock := newTestClock()
+	s := newScheduler(SchedulerOptions{Clock: clock})
+
+	var runs atomic.Int32
+	job := s.Add("job", IntervalSchedule(time.Second, 100*time.Millisecond), OverlapSkip,
+		func(ctx Context) status.Status {
+			runs.Add(1)
+			return status.OK
+		})
+	testRunScheduler(t, s)
+	testEventually(t, func() bool { return !job.NextRun().IsZero() })
+
+	start := clock.Now()
+	for i := 1; i <= 20; i++ {
+		next := job.NextRun()
+		base := start.Add(time.Duration(i) * time.Second)
+		require.False(t, next.Before(base))
+		require.True(t, next.Before(base.Add(100*time.Millisecond)))
+
+		clock.Advance(next.Sub(clock.Now()))
+		testEventually(t, func() bool { return runs.Load() == int32(i) })
+		testEventually(t, func() bool { return job.NextRun() != next })
+	}
+}
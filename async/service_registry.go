@@ -0,0 +1,379 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// ServiceRegistry starts and stops services in the dependency order.
+//
+// Start starts services in topological order, a service is started only after its dependencies
+// are running. If a service fails to start, the already started services are stopped in reverse
+// order. Stop stops services in reverse start order, each service within its own stop timeout.
+// Dependencies of a service which does not stop in time are left running, a subsequent Stop
+// retries to stop them.
+//
+// Example:
+//
+//	reg := async.NewServiceRegistry(async.ServiceRegistryOptions{StopTimeout: 10 * time.Second})
+//	reg.Add("db", db)
+//	reg.Add("cache", cache, "db")
+//	reg.Add("server", server, "db", "cache")
+//
+//	if st := reg.Start(ctx); !st.OK() {
+//		return st
+//	}
+//	defer reg.Stop()
+type ServiceRegistry interface {
+	// Add adds a service with its dependencies, returns an error if the name is already added,
+	// or if the registry is started.
+	Add(name string, s Service, deps ...string) status.Status
+
+	// Start starts services in dependency order, awaits each service running flag.
+	// The method detects dependency cycles and unknown dependencies, and stops the started
+	// services when a service fails to start or the context is cancelled.
+	// The method returns a cancelled status if Stop is called during the start.
+	Start(ctx Context) status.Status
+
+	// Stop stops services in reverse start order, returns the first stop timeout or
+	// a non-OK service exit status, a cancelled exit status is considered OK.
+	// The method interrupts an in-progress start, and stops the already started services.
+	Stop() status.Status
+}
+
+// ServiceRegistryOptions specifies the service registry options.
+type ServiceRegistryOptions struct {
+	// StartTimeout is the max time to await a service running flag, zero means no timeout.
+	StartTimeout time.Duration
+
+	// StopTimeout is the max time to await a service stop, zero means no timeout.
+	StopTimeout time.Duration
+}
+
+// NewServiceRegistry returns a new service registry.
+func NewServiceRegistry(opts ServiceRegistryOptions) ServiceRegistry {
+	return newServiceRegistry(opts)
+}
+
+// internal
+
+var _ ServiceRegistry = (*serviceRegistry)(nil)
+
+type serviceRegistry struct {
+	opts ServiceRegistryOptions
+
+	mu      sync.Mutex
+	entries []*serviceEntry
+	names   map[string]*serviceEntry
+	start   *serviceStart   // in-progress start, nil when not starting
+	started []*serviceEntry // nil when not started
+}
+
+type serviceEntry struct {
+	name string
+	svc  Service
+	deps []string
+}
+
+// serviceStart is an in-progress start, which can be interrupted by Stop.
+type serviceStart struct {
+	stop    CancelContext // cancelled by Stop
+	done    chan struct{} // closed when the start completes
+	stopped bool          // Stop has been called, guarded by the registry lock
+}
+
+func newServiceRegistry(opts ServiceRegistryOptions) *serviceRegistry {
+	return &serviceRegistry{
+		opts:  opts,
+		names: make(map[string]*serviceEntry),
+	}
+}
+
+// Add adds a service with its dependencies, returns an error if the name is already added,
+// or if the registry is started.
+func (r *serviceRegistry) Add(name string, svc Service, deps ...string) status.Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.started != nil {
+		return status.Errorf("cannot add service %q to started registry", name)
+	}
+	if _, ok := r.names[name]; ok {
+		return status.Errorf("service %q already added", name)
+	}
+
+	e := &serviceEntry{
+		name: name,
+		svc:  svc,
+		deps: append([]string(nil), deps...),
+	}
+	r.entries = append(r.entries, e)
+	r.names[name] = e
+	return status.OK
+}
+
+// Start starts services in dependency order, awaits each service running flag.
+// The method detects dependency cycles and unknown dependencies, and stops the started
+// services when a service fails to start or the context is cancelled.
+// The method returns a cancelled status if Stop is called during the start.
+func (r *serviceRegistry) Start(ctx Context) status.Status {
+	r.mu.Lock()
+	if r.started != nil || r.start != nil {
+		r.mu.Unlock()
+		return status.OK
+	}
+
+	order, st := r.sort()
+	if !st.OK() {
+		r.mu.Unlock()
+		return st
+	}
+
+	start := &serviceStart{
+		stop: NewContext(),
+		done: make(chan struct{}),
+	}
+	r.start = start
+	r.mu.Unlock()
+
+	// Start services without the lock, so that Stop can interrupt the start
+	started, st := r.startServices(ctx, start.stop, order)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	defer close(start.done)
+	defer start.stop.Free()
+
+	r.start = nil
+	switch {
+	case start.stopped:
+		// Stop awaits the start, and stops the started services
+		r.started = started
+		return status.Cancelledf("service registry is stopped")
+
+	case !st.OK():
+		r.started, _ = r.stopServices(started)
+		return st
+	}
+
+	r.started = started
+	return status.OK
+}
+
+// Stop stops services in reverse start order, returns the first stop timeout or
+// a non-OK service exit status, a cancelled exit status is considered OK.
+// The method interrupts an in-progress start, and stops the already started services.
+func (r *serviceRegistry) Stop() status.Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Interrupt start
+	if start := r.start; start != nil {
+		start.stopped = true
+		start.stop.Cancel()
+
+		r.mu.Unlock()
+		<-start.done
+		r.mu.Lock()
+	}
+
+	remaining, st := r.stopServices(r.started)
+	r.started = remaining
+	return st
+}
+
+// private
+
+// sort returns services in topological order, preserves the insertion order
+// of independent services.
+func (r *serviceRegistry) sort() ([]*serviceEntry, status.Status) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	order := make([]*serviceEntry, 0, len(r.entries))
+	states := make(map[*serviceEntry]int, len(r.entries))
+	path := make([]string, 0, len(r.entries))
+
+	var visit func(e *serviceEntry) status.Status
+	visit = func(e *serviceEntry) status.Status {
+		switch states[e] {
+		case visited:
+			return status.OK
+		case visiting:
+			cycle := append(path, e.name)
+			for i, name := range cycle {
+				if name == e.name {
+					cycle = cycle[i:]
+					break
+				}
+			}
+			return status.Errorf("service dependency cycle: %v", strings.Join(cycle, " -> "))
+		}
+
+		states[e] = visiting
+		path = append(path, e.name)
+
+		for _, name := range e.deps {
+			dep, ok := r.names[name]
+			if !ok {
+				return status.Errorf("service %q depends on unknown service %q", e.name, name)
+			}
+			if st := visit(dep); !st.OK() {
+				return st
+			}
+		}
+
+		states[e] = visited
+		path = path[:len(path)-1]
+		order = append(order, e)
+		return status.OK
+	}
+
+	for _, e := range r.entries {
+		if st := visit(e); !st.OK() {
+			return nil, st
+		}
+	}
+	return order, status.OK
+}
+
+// startServices starts services in order, returns the started services.
+func (r *serviceRegistry) startServices(
+	ctx Context, stop Context, order []*serviceEntry) ([]*serviceEntry, status.Status) {
+	started := make([]*serviceEntry, 0, len(order))
+	for _, e := range order {
+		st := r.startService(ctx, stop, e)
+		if !st.OK() {
+			return started, st
+		}
+
+		started = append(started, e)
+	}
+	return started, status.OK
+}
+
+// startService starts a service and awaits its running flag, stops the service on failure.
+// A service which exits with OK during start is considered started.
+func (r *serviceRegistry) startService(ctx Context, stop Context, e *serviceEntry) status.Status {
+	if st := e.svc.Start(); !st.OK() {
+		return st.WrapTextf("service %q failed to start", e.name)
+	}
+
+	var timeout <-chan time.Time
+	if r.opts.StartTimeout > 0 {
+		timer := time.NewTimer(r.opts.StartTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-e.svc.Running().Wait():
+		// Check immediate exit
+		select {
+		case <-e.svc.Wait():
+		default:
+			return status.OK
+		}
+
+	case <-e.svc.Wait():
+
+	case <-timeout:
+		r.stopService(e)
+		return status.Timeoutf("service %q start timeout", e.name)
+
+	case <-ctx.Wait():
+		r.stopService(e)
+		return ctx.Status()
+
+	case <-stop.Wait():
+		r.stopService(e)
+		return stop.Status()
+	}
+
+	// Service exited
+	st := e.svc.Status()
+	if st.OK() {
+		return status.OK
+	}
+	return st.WrapTextf("service %q failed to start", e.name)
+}
+
+// stopServices stops services in reverse order, returns the services which are still running
+// and the first error. Dependencies of a service which does not stop in time are left running.
+func (r *serviceRegistry) stopServices(entries []*serviceEntry) ([]*serviceEntry, status.Status) {
+	result := status.OK
+	running := make(map[string]struct{}) // services which are still running
+	keep := make(map[string]struct{})    // dependencies of running services
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+
+		// Leave dependency running
+		if _, ok := keep[e.name]; ok {
+			running[e.name] = struct{}{}
+			for _, dep := range e.deps {
+				keep[dep] = struct{}{}
+			}
+			continue
+		}
+
+		st := r.stopService(e)
+		if st.Code == status.CodeTimeout {
+			running[e.name] = struct{}{}
+			for _, dep := range e.deps {
+				keep[dep] = struct{}{}
+			}
+		}
+		if !st.OK() && result.OK() {
+			result = st
+		}
+	}
+
+	if len(running) == 0 {
+		return nil, result
+	}
+
+	remaining := make([]*serviceEntry, 0, len(running))
+	for _, e := range entries {
+		if _, ok := running[e.name]; ok {
+			remaining = append(remaining, e)
+		}
+	}
+	return remaining, result
+}
+
+// stopService stops a service and awaits it within the stop timeout,
+// returns a timeout or a non-OK service exit status.
+func (r *serviceRegistry) stopService(e *serviceEntry) status.Status {
+	stop := e.svc.Stop()
+
+	var timeout <-chan time.Time
+	if r.opts.StopTimeout > 0 {
+		timer := time.NewTimer(r.opts.StopTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-stop:
+	case <-timeout:
+		return status.Timeoutf("service %q stop timeout", e.name)
+	}
+
+	st := e.svc.Status()
+	switch st.Code {
+	case status.CodeOK, status.CodeNone, status.CodeCancelled:
+		return status.OK
+	}
+	return st.WrapTextf("service %q stopped with error", e.name)
+}
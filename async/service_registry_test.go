@@ -0,0 +1,206 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRegistryLog struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (l *testRegistryLog) add(event string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events = append(l.events, event)
+}
+
+func (l *testRegistryLog) get() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]string(nil), l.events...)
+}
+
+func testRegistryService(log *testRegistryLog, name string) Service {
+	return NewService(func(ctx Context) status.Status {
+		log.add("start " + name)
+		<-ctx.Wait()
+		log.add("stop " + name)
+		return ctx.Status()
+	})
+}
+
+// Start
+
+func TestServiceRegistry_Start__should_start_services_in_dependency_order(t *testing.T) {
+	log := &testRegistryLog{}
+	r := newServiceRegistry(ServiceRegistryOptions{})
+	r.Add("server", testRegistryService(log, "server"), "db", "cache")
+	r.Add("cache", testRegistryService(log, "cache"), "db")
+	r.Add("db", testRegistryService(log, "db"))
+
+	st := r.Start(NoContext())
+	require.True(t, st.OK())
+	assert.Equal(t, []string{"start db", "start cache", "start server"}, log.get())
+
+	st = r.Stop()
+	require.True(t, st.OK())
+	assert.Equal(t, []string{
+		"start db", "start cache", "start server",
+		"stop server", "stop cache", "stop db",
+	}, log.get())
+}
+
+func TestServiceRegistry_Start__should_return_error_on_cycle(t *testing.T) {
+	log := &testRegistryLog{}
+	r := newServiceRegistry(ServiceRegistryOptions{})
+	r.Add("a", testRegistryService(log, "a"), "b")
+	r.Add("b", testRegistryService(log, "b"), "c")
+	r.Add("c", testRegistryService(log, "c"), "a")
+
+	st := r.Start(NoContext())
+	assert.Equal(t, status.CodeError, st.Code)
+	assert.Contains(t, st.Message, "a -> b -> c -> a")
+	assert.Empty(t, log.get())
+}
+
+func TestServiceRegistry_Start__should_return_error_on_unknown_dependency(t *testing.T) {
+	log := &testRegistryLog{}
+	r := newServiceRegistry(ServiceRegistryOptions{})
+	r.Add("a", testRegistryService(log, "a"), "b")
+
+	st := r.Start(NoContext())
+	assert.Equal(t, status.CodeError, st.Code)
+	assert.Contains(t, st.Message, `unknown service "b"`)
+}
+
+func TestServiceRegistry_Start__should_rollback_started_services_on_failure(t *testing.T) {
+	log := &testRegistryLog{}
+	r := newServiceRegistry(ServiceRegistryOptions{})
+	r.Add("db", testRegistryService(log, "db"))
+	r.Add("cache", testRegistryService(log, "cache"), "db")
+	r.Add("server", &testSlowService{Service: NewService(func(ctx Context) status.Status {
+		return status.Error("test")
+	})}, "cache")
+
+	st := r.Start(NoContext())
+	assert.Equal(t, status.CodeError, st.Code)
+	assert.Contains(t, st.Message, `service "server" failed to start`)
+	assert.Equal(t, []string{
+		"start db", "start cache",
+		"stop cache", "stop db",
+	}, log.get())
+}
+
+func TestServiceRegistry_Start__should_rollback_on_start_timeout(t *testing.T) {
+	log := &testRegistryLog{}
+	r := newServiceRegistry(ServiceRegistryOptions{StartTimeout: 10 * time.Millisecond})
+	r.Add("db", testRegistryService(log, "db"))
+	r.Add("slow", &testSlowService{Service: testRegistryService(log, "slow")}, "db")
+
+	st := r.Start(NoContext())
+	assert.Equal(t, status.CodeTimeout, st.Code)
+	assert.Equal(t, "stop db", log.get()[len(log.get())-1])
+}
+
+func TestServiceRegistry_Start__should_be_interrupted_by_stop(t *testing.T) {
+	log := &testRegistryLog{}
+	r := newServiceRegistry(ServiceRegistryOptions{})
+	r.Add("db", testRegistryService(log, "db"))
+	r.Add("slow", &testSlowService{Service: testRegistryService(log, "slow")}, "db")
+
+	result := make(chan status.Status, 1)
+	go func() {
+		result <- r.Start(NoContext())
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(log.get()) == 2
+	}, time.Second, time.Millisecond)
+
+	st := r.Stop()
+	require.True(t, st.OK())
+
+	st = <-result
+	assert.Equal(t, status.CodeCancelled, st.Code)
+	assert.Equal(t, []string{"start db", "start slow", "stop slow", "stop db"}, log.get())
+}
+
+// Stop
+
+func TestServiceRegistry_Stop__should_return_service_error(t *testing.T) {
+	r := newServiceRegistry(ServiceRegistryOptions{})
+	r.Add("db", NewService(func(ctx Context) status.Status {
+		<-ctx.Wait()
+		return status.Error("flush failed")
+	}))
+
+	st := r.Start(NoContext())
+	require.True(t, st.OK())
+
+	st = r.Stop()
+	assert.Equal(t, status.CodeError, st.Code)
+	assert.Contains(t, st.Message, `service "db" stopped with error`)
+}
+
+func TestServiceRegistry_Stop__should_return_ok_when_services_cancelled(t *testing.T) {
+	log := &testRegistryLog{}
+	r := newServiceRegistry(ServiceRegistryOptions{})
+	r.Add("db", testRegistryService(log, "db"))
+
+	st := r.Start(NoContext())
+	require.True(t, st.OK())
+
+	st = r.Stop()
+	assert.True(t, st.OK())
+}
+
+func TestServiceRegistry_Stop__should_leave_dependencies_running_on_timeout(t *testing.T) {
+	log := &testRegistryLog{}
+	release := make(chan struct{})
+
+	r := newServiceRegistry(ServiceRegistryOptions{StopTimeout: 10 * time.Millisecond})
+	r.Add("db", testRegistryService(log, "db"))
+	r.Add("stuck", NewService(func(ctx Context) status.Status {
+		<-release
+		return status.OK
+	}), "db")
+
+	st := r.Start(NoContext())
+	require.True(t, st.OK())
+
+	st = r.Stop()
+	assert.Equal(t, status.CodeTimeout, st.Code)
+	assert.Contains(t, st.Message, `"stuck"`)
+	assert.Equal(t, []string{"start db"}, log.get())
+
+	// Retry after the dependent exits
+	close(release)
+
+	st = r.Stop()
+	assert.True(t, st.OK())
+	assert.Equal(t, []string{"start db", "stop db"}, log.get())
+}
+
+// private
+
+// testSlowService never reports running.
+type testSlowService struct {
+	Service
+}
+
+func (s *testSlowService) Running() Flag {
+	return UnsetFlag()
+}
@@ -14,6 +14,7 @@ import (
 // Stream is an async stream of values.
 type Stream[T any] interface {
 	// Next returns the next value from the stream, or false if the stream has ended.
+	// The end status is OK or status.End.
 	Next(ctx context.Context) (T, bool, status.Status)
 
 	// Internal
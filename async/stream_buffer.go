@@ -0,0 +1,94 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"time"
+
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// BufferStream returns a stream which prefetches up to n values from the input stream
+// in a background goroutine, panics if n is not positive.
+//
+// The goroutine is started on the first Next call. The returned stream owns the input stream,
+// Free cancels the prefetch, awaits the goroutine, and frees the input stream.
+func BufferStream[T any](s Stream[T], n int) Stream[T] {
+	if n <= 0 {
+		panic("stream buffer size must be positive")
+	}
+	return newStreamPump([]Stream[T]{s}, n)
+}
+
+// BatchStream returns a stream which groups values from the input stream into batches
+// of up to n values, panics if n is not positive.
+//
+// A batch is returned when it is full, or when max delay passes since its first value,
+// a zero delay returns only the values which are already available. A partial batch is
+// also returned when the input stream ends or the context is cancelled, the end or context
+// status is returned on the next call.
+//
+// The input stream is prefetched by a background goroutine, see BufferStream.
+func BatchStream[T any](s Stream[T], n int, maxDelay time.Duration) Stream[[]T] {
+	if n <= 0 {
+		panic("stream batch size must be positive")
+	}
+
+	return &batchStream[T]{
+		pump:     newStreamPump([]Stream[T]{s}, n),
+		size:     n,
+		maxDelay: maxDelay,
+	}
+}
+
+// internal
+
+var _ Stream[[]any] = (*batchStream[any])(nil)
+
+// closedTimeout is used by batches without a max delay.
+var closedTimeout = func() <-chan time.Time {
+	ch := make(chan time.Time)
+	close(ch)
+	return ch
+}()
+
+type batchStream[T any] struct {
+	pump     *streamPump[T]
+	size     int
+	maxDelay time.Duration
+}
+
+// Next returns the next batch from the stream, or false if the stream has ended.
+func (s *batchStream[T]) Next(ctx Context) ([]T, bool, status.Status) {
+	v, ok, st := s.pump.next(ctx, nil)
+	if !ok {
+		return nil, false, st
+	}
+
+	batch := make([]T, 1, s.size)
+	batch[0] = v
+
+	// Await more values
+	timeout := closedTimeout
+	if s.maxDelay > 0 {
+		timer := time.NewTimer(s.maxDelay)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for len(batch) < s.size {
+		v, ok, _ := s.pump.next(ctx, timeout)
+		if !ok {
+			break
+		}
+		batch = append(batch, v)
+	}
+	return batch, true, status.OK
+}
+
+// Free frees the stream.
+func (s *batchStream[T]) Free() {
+	s.pump.Free()
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Buffer
+
+func TestBufferStream__should_prefetch_values(t *testing.T) {
+	src := newTestStream(1, 2, 3)
+	s := BufferStream[int](src, 2)
+
+	v, ok, st := s.Next(NoContext())
+	require.True(t, ok)
+	require.True(t, st.OK())
+	assert.Equal(t, 1, v)
+
+	// Await prefetch
+	require.Eventually(t, func() bool {
+		return len(s.(*streamPump[int]).items) == 2
+	}, time.Second, time.Millisecond)
+
+	values, st := testStreamAll(t, s)
+	assert.Equal(t, status.End, st)
+	assert.Equal(t, []int{2, 3}, values)
+
+	s.Free()
+	assert.True(t, src.freed.Load())
+}
+
+func TestBufferStream__should_propagate_error(t *testing.T) {
+	src := newTestStream(1)
+	src.end = status.Errorf("test error")
+
+	s := BufferStream[int](src, 4)
+	defer s.Free()
+
+	values, st := testStreamAll(t, s)
+	assert.Equal(t, status.CodeError, st.Code)
+	assert.Equal(t, []int{1}, values)
+}
+
+// Batch
+
+func TestBatchStream__should_return_full_batches(t *testing.T) {
+	src := newTestStream(1, 2, 3, 4, 5)
+	s := BatchStream[int](src, 2, time.Second)
+
+	batches, st := testStreamAll(t, s)
+	assert.Equal(t, status.End, st)
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, batches)
+
+	s.Free()
+	assert.True(t, src.freed.Load())
+}
+
+func TestBatchStream__should_return_partial_batch_after_max_delay(t *testing.T) {
+	q := NewBoundedQueue[int](4)
+	s := BatchStream(StreamFromQueue(q), 10, 10*time.Millisecond)
+	defer s.Free()
+
+	q.TryPush(1)
+	q.TryPush(2)
+
+	batch, ok, st := s.Next(NoContext())
+	require.True(t, ok)
+	require.True(t, st.OK())
+	assert.Equal(t, []int{1, 2}, batch)
+}
+
+func TestBatchStream__should_return_partial_batch_when_cancelled(t *testing.T) {
+	q := NewBoundedQueue[int](4)
+	s := BatchStream(StreamFromQueue(q), 10, time.Hour)
+	defer s.Free()
+
+	ctx := NewContext()
+	defer ctx.Free()
+
+	q.TryPush(1)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		ctx.Cancel()
+	}()
+
+	batch, ok, st := s.Next(ctx)
+	require.True(t, ok)
+	require.True(t, st.OK())
+	assert.Equal(t, []int{1}, batch)
+
+	_, ok, st = s.Next(ctx)
+	assert.False(t, ok)
+	assert.Equal(t, status.CodeCancelled, st.Code)
+}
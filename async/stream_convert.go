@@ -0,0 +1,87 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"github.com/basecomplextech/baselibrary/iterator"
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// StreamFromQueue returns a stream which polls values from a bounded queue, and awaits new
+// values when the queue is empty. The stream returns status.End when the queue is closed
+// and empty, or a context status when cancelled.
+func StreamFromQueue[T any](q BoundedQueue[T]) Stream[T] {
+	return NewStream(func(ctx Context) (v T, _ bool, _ status.Status) {
+		v, st := q.PollContext(ctx)
+		if !st.OK() {
+			return v, false, st
+		}
+		return v, true, status.OK
+	})
+}
+
+// StreamToQueue reads values from a stream and pushes them to a queue until the stream ends.
+// The method returns OK when the stream ends, or an error or context status.
+// The stream is not freed.
+func StreamToQueue[T any](ctx Context, s Stream[T], q Queue[T]) status.Status {
+	for {
+		v, ok, st := s.Next(ctx)
+		switch {
+		case st.Code == status.CodeEnd:
+			return status.OK
+		case !st.OK():
+			return st
+		case !ok:
+			return status.OK
+		}
+
+		q.Push(v)
+	}
+}
+
+// StreamFromIter returns a stream which reads values from an iterator, the iterator end
+// is returned as status.End. The iterator is not interrupted by the context cancellation,
+// the context is checked before each read.
+//
+// The returned stream owns the iterator and frees it.
+func StreamFromIter[T any](it iterator.IterStatus[T]) Stream[T] {
+	next := func(ctx Context) (v T, _ bool, _ status.Status) {
+		if ctx.Done() {
+			return v, false, ctx.Status()
+		}
+
+		v, ok, st := it.Next()
+		switch {
+		case !st.OK():
+			return v, false, st
+		case !ok:
+			return v, false, status.End
+		}
+		return v, true, status.OK
+	}
+
+	return NewStreamFree(next, it.Free)
+}
+
+// StreamToIter returns an iterator which reads values from a stream using the context,
+// the stream end status is returned as the iterator end, i.e. false and OK.
+//
+// The returned iterator owns the stream and frees it.
+func StreamToIter[T any](ctx Context, s Stream[T]) iterator.IterStatus[T] {
+	next := func() (v T, _ bool, _ status.Status) {
+		v, ok, st := s.Next(ctx)
+		switch {
+		case st.Code == status.CodeEnd:
+			return v, false, status.OK
+		case !st.OK():
+			return v, false, st
+		case !ok:
+			return v, false, status.OK
+		}
+		return v, true, status.OK
+	}
+
+	return iterator.NewFreeStatus(next, s.Free)
+}
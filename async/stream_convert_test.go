@@ -0,0 +1,106 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/iterator"
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Queue
+
+func TestStreamFromQueue__should_return_end_when_queue_closed(t *testing.T) {
+	q := NewBoundedQueue[int](4)
+	q.TryPush(1)
+	q.TryPush(2)
+	q.Close()
+
+	s := StreamFromQueue(q)
+	defer s.Free()
+
+	values, st := testStreamAll(t, s)
+	assert.Equal(t, status.CodeEnd, st.Code)
+	assert.Equal(t, []int{1, 2}, values)
+}
+
+func TestStreamFromQueue__should_return_context_status_when_cancelled(t *testing.T) {
+	q := NewBoundedQueue[int](1)
+	q.TryPush(1)
+
+	s := StreamFromQueue(q)
+	defer s.Free()
+
+	v, ok, st := s.Next(NoContext())
+	require.True(t, ok)
+	require.True(t, st.OK())
+	assert.Equal(t, 1, v)
+
+	ctx := NewContext()
+	defer ctx.Free()
+	ctx.Cancel()
+
+	_, ok, st = s.Next(ctx)
+	assert.False(t, ok)
+	assert.Equal(t, status.CodeCancelled, st.Code)
+}
+
+func TestStreamToQueue__should_push_values_until_end(t *testing.T) {
+	src := newTestStream(1, 2, 3)
+	q := NewQueue[int]()
+
+	st := StreamToQueue[int](NoContext(), src, q)
+	require.True(t, st.OK())
+	assert.Equal(t, 3, q.Len())
+	assert.False(t, src.freed.Load())
+}
+
+// Iterator
+
+func TestStreamFromIter__should_return_end(t *testing.T) {
+	it := iterator.NewNoopStatus(iteratorValues(1, 2))
+	s := StreamFromIter(it)
+	defer s.Free()
+
+	values, st := testStreamAll(t, s)
+	assert.Equal(t, status.End, st)
+	assert.Equal(t, []int{1, 2}, values)
+}
+
+func TestStreamToIter__should_convert_end_to_ok(t *testing.T) {
+	src := newTestStream(1, 2)
+	it := StreamToIter[int](NoContext(), src)
+
+	var values []int
+	for {
+		v, ok, st := it.Next()
+		require.True(t, st.OK())
+		if !ok {
+			break
+		}
+		values = append(values, v)
+	}
+	assert.Equal(t, []int{1, 2}, values)
+
+	it.Free()
+	assert.True(t, src.freed.Load())
+}
+
+// private
+
+func iteratorValues[T any](values ...T) iterator.NextFuncStatus[T] {
+	return func() (v T, _ bool, _ status.Status) {
+		if len(values) == 0 {
+			return v, false, status.OK
+		}
+
+		v = values[0]
+		values = values[1:]
+		return v, true, status.OK
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// MapStream returns a stream which maps values from the input stream.
+// The returned stream owns the input stream and frees it.
+func MapStream[T, V any](s Stream[T], fn func(T) (V, status.Status)) Stream[V] {
+	return &mapStream[T, V]{
+		src: s,
+		fn:  fn,
+	}
+}
+
+// FilterStream returns a stream which skips values from the input stream
+// for which the function returns false.
+// The returned stream owns the input stream and frees it.
+func FilterStream[T any](s Stream[T], fn func(T) bool) Stream[T] {
+	return &filterStream[T]{
+		src: s,
+		fn:  fn,
+	}
+}
+
+// internal
+
+var (
+	_ Stream[any] = (*mapStream[any, any])(nil)
+	_ Stream[any] = (*filterStream[any])(nil)
+)
+
+type mapStream[T, V any] struct {
+	src Stream[T]
+	fn  func(T) (V, status.Status)
+}
+
+// Next returns the next value from the stream, or false if the stream has ended.
+func (s *mapStream[T, V]) Next(ctx Context) (v V, _ bool, _ status.Status) {
+	v0, ok, st := s.src.Next(ctx)
+	switch {
+	case !st.OK():
+		return v, false, st
+	case !ok:
+		return v, false, status.OK
+	}
+
+	v, st = s.fn(v0)
+	if !st.OK() {
+		return v, false, st
+	}
+	return v, true, status.OK
+}
+
+// Free frees the stream.
+func (s *mapStream[T, V]) Free() {
+	s.src.Free()
+}
+
+// filter
+
+type filterStream[T any] struct {
+	src Stream[T]
+	fn  func(T) bool
+}
+
+// Next returns the next value from the stream, or false if the stream has ended.
+func (s *filterStream[T]) Next(ctx Context) (v T, _ bool, _ status.Status) {
+	for {
+		v, ok, st := s.src.Next(ctx)
+		switch {
+		case !st.OK():
+			return v, false, st
+		case !ok:
+			return v, false, status.OK
+		}
+
+		if s.fn(v) {
+			return v, true, status.OK
+		}
+	}
+}
+
+// Free frees the stream.
+func (s *filterStream[T]) Free() {
+	s.src.Free()
+}
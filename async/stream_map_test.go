@@ -0,0 +1,108 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testStream returns values and then status.End, records when freed.
+type testStream[T any] struct {
+	values []T
+	end    status.Status
+	freed  atomic.Bool
+}
+
+func newTestStream[T any](values ...T) *testStream[T] {
+	return &testStream[T]{
+		values: values,
+		end:    status.End,
+	}
+}
+
+func (s *testStream[T]) Next(ctx Context) (v T, _ bool, _ status.Status) {
+	if ctx.Done() {
+		return v, false, ctx.Status()
+	}
+	if len(s.values) == 0 {
+		return v, false, s.end
+	}
+
+	v = s.values[0]
+	s.values = s.values[1:]
+	return v, true, status.OK
+}
+
+func (s *testStream[T]) Free() {
+	s.freed.Store(true)
+}
+
+// testStreamAll reads all values from a stream, returns the end status.
+func testStreamAll[T any](t *testing.T, s Stream[T]) ([]T, status.Status) {
+	ctx := NoContext()
+
+	var result []T
+	for {
+		v, ok, st := s.Next(ctx)
+		if !ok {
+			return result, st
+		}
+		require.True(t, st.OK())
+		result = append(result, v)
+	}
+}
+
+// Map
+
+func TestMapStream__should_map_values(t *testing.T) {
+	src := newTestStream(1, 2, 3)
+	s := MapStream(src, func(v int) (string, status.Status) {
+		return strconv.Itoa(v), status.OK
+	})
+
+	values, st := testStreamAll(t, s)
+	assert.Equal(t, status.End, st)
+	assert.Equal(t, []string{"1", "2", "3"}, values)
+
+	s.Free()
+	assert.True(t, src.freed.Load())
+}
+
+func TestMapStream__should_return_map_error(t *testing.T) {
+	src := newTestStream(1, 2, 3)
+	s := MapStream(src, func(v int) (int, status.Status) {
+		if v == 2 {
+			return 0, status.Errorf("test error")
+		}
+		return v, status.OK
+	})
+	defer s.Free()
+
+	values, st := testStreamAll(t, s)
+	assert.Equal(t, status.CodeError, st.Code)
+	assert.Equal(t, []int{1}, values)
+}
+
+// Filter
+
+func TestFilterStream__should_skip_values(t *testing.T) {
+	src := newTestStream(1, 2, 3, 4)
+	s := FilterStream[int](src, func(v int) bool {
+		return v%2 == 0
+	})
+
+	values, st := testStreamAll(t, s)
+	assert.Equal(t, status.End, st)
+	assert.Equal(t, []int{2, 4}, values)
+
+	s.Free()
+	assert.True(t, src.freed.Load())
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"sync"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// MergeStreams returns a stream which returns values from all input streams as they arrive.
+//
+// Each input stream is read by a separate goroutine started on the first Next call.
+// The merged stream ends when all input streams end, and returns the end status of the last
+// one, i.e. OK or status.End. An error from any input stream ends the merged stream.
+//
+// The returned stream owns the input streams. Free cancels their reads, awaits the goroutines,
+// and frees the input streams, so the input streams must respect the context cancellation.
+func MergeStreams[T any](streams ...Stream[T]) Stream[T] {
+	return newStreamPump(streams, 0)
+}
+
+// internal
+
+var _ Stream[any] = (*streamPump[any])(nil)
+
+// streamPump reads source streams in background goroutines, and sends their values
+// to a channel. The pump is used to merge, buffer and batch streams.
+type streamPump[T any] struct {
+	ctx     CancelContext
+	sources []Stream[T]
+	items   chan streamItem[T]
+	wg      sync.WaitGroup
+
+	// consumer state, streams are not safe for concurrent reads
+	started bool
+	active  int           // sources which have not ended
+	end     status.Status // end or error status, none when not ended
+	freed   bool
+}
+
+type streamItem[T any] struct {
+	value T
+	ok    bool
+	st    status.Status
+}
+
+func newStreamPump[T any](sources []Stream[T], size int) *streamPump[T] {
+	return &streamPump[T]{
+		ctx:     NewContext(),
+		sources: sources,
+		items:   make(chan streamItem[T], size),
+		active:  len(sources),
+	}
+}
+
+// Next returns the next value from the stream, or false if the stream has ended.
+func (p *streamPump[T]) Next(ctx Context) (T, bool, status.Status) {
+	return p.next(ctx, nil)
+}
+
+// Free frees the stream.
+func (p *streamPump[T]) Free() {
+	if p.freed {
+		return
+	}
+	p.freed = true
+
+	p.ctx.Cancel()
+	p.wg.Wait()
+	p.ctx.Free()
+
+	for _, s := range p.sources {
+		s.Free()
+	}
+}
+
+// private
+
+// next returns the next value, or false and an end status, or false and a context status.
+// The method returns false and none when the timeout channel fires before a value is received.
+func (p *streamPump[T]) next(ctx Context, timeout <-chan time.Time) (v T, _ bool, _ status.Status) {
+	if p.end.Code != status.CodeNone {
+		return v, false, p.end
+	}
+	if len(p.sources) == 0 {
+		p.end = status.End
+		return v, false, p.end
+	}
+	p.start()
+
+	for {
+		var item streamItem[T]
+
+		// Prefer available items to timeout
+		select {
+		case item = <-p.items:
+		default:
+			select {
+			case item = <-p.items:
+			case <-timeout:
+				return v, false, status.None
+			case <-ctx.Wait():
+				return v, false, ctx.Status()
+			}
+		}
+
+		switch {
+		case item.ok:
+			return item.value, true, status.OK
+
+		case item.st.OK() || item.st.Code == status.CodeEnd:
+			p.active--
+			if p.active > 0 {
+				continue
+			}
+		}
+
+		p.end = item.st
+		return v, false, p.end
+	}
+}
+
+// start starts source goroutines on the first read.
+func (p *streamPump[T]) start() {
+	if p.started {
+		return
+	}
+	p.started = true
+
+	p.wg.Add(len(p.sources))
+	for _, s := range p.sources {
+		go p.pump(s)
+	}
+}
+
+// pump reads a source until it ends or the pump is freed.
+func (p *streamPump[T]) pump(s Stream[T]) {
+	defer p.wg.Done()
+
+	for {
+		v, ok, st := s.Next(p.ctx)
+		item := streamItem[T]{
+			value: v,
+			ok:    ok && st.OK(),
+			st:    st,
+		}
+
+		select {
+		case p.items <- item:
+		case <-p.ctx.Wait():
+			return
+		}
+
+		if !item.ok {
+			return
+		}
+	}
+}
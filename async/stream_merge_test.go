@@ -0,0 +1,122 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeStreams__should_merge_values_until_all_end(t *testing.T) {
+	src0 := newTestStream(1, 2, 3)
+	src1 := newTestStream(10, 20)
+	s := MergeStreams[int](src0, src1)
+
+	values, st := testStreamAll(t, s)
+	assert.Equal(t, status.End, st)
+
+	slices.Sort(values)
+	assert.Equal(t, []int{1, 2, 3, 10, 20}, values)
+
+	// Repeat end
+	_, ok, st := s.Next(NoContext())
+	assert.False(t, ok)
+	assert.Equal(t, status.End, st)
+
+	s.Free()
+	assert.True(t, src0.freed.Load())
+	assert.True(t, src1.freed.Load())
+}
+
+func TestMergeStreams__should_return_error(t *testing.T) {
+	src0 := newTestStream[int]()
+	src0.end = status.Errorf("test error")
+	src1 := newTestStream(1, 2, 3)
+
+	s := MergeStreams[int](src0, src1)
+	defer s.Free()
+
+	_, st := testStreamAll(t, s)
+	assert.Equal(t, status.CodeError, st.Code)
+}
+
+func TestMergeStreams__should_return_end_when_no_streams(t *testing.T) {
+	s := MergeStreams[int]()
+	defer s.Free()
+
+	_, ok, st := s.Next(NoContext())
+	assert.False(t, ok)
+	assert.Equal(t, status.End, st)
+}
+
+func TestMergeStreams__should_end_when_queue_closed(t *testing.T) {
+	q := NewBoundedQueue[int](4)
+	s := MergeStreams(StreamFromQueue(q), newTestStream(10))
+	defer s.Free()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.TryPush(1)
+		q.Close()
+	}()
+
+	values, st := testStreamAll(t, s)
+	assert.Equal(t, status.CodeEnd, st.Code)
+
+	slices.Sort(values)
+	assert.Equal(t, []int{1, 10}, values)
+}
+
+func TestMergeStreams__should_return_context_status_when_cancelled(t *testing.T) {
+	q := NewBoundedQueue[int](4)
+	s := MergeStreams(StreamFromQueue(q))
+	defer s.Free()
+
+	ctx := NewContext()
+	defer ctx.Free()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		ctx.Cancel()
+	}()
+
+	_, ok, st := s.Next(ctx)
+	assert.False(t, ok)
+	assert.Equal(t, status.CodeCancelled, st.Code)
+
+	// Read after cancel
+	q.TryPush(1)
+	v, ok, st := s.Next(NoContext())
+	require.True(t, ok)
+	require.True(t, st.OK())
+	assert.Equal(t, 1, v)
+}
+
+func TestMergeStreams_Free__should_cancel_reads(t *testing.T) {
+	q := NewBoundedQueue[int](4)
+	s := MergeStreams(StreamFromQueue(q))
+
+	q.TryPush(1)
+	v, ok, _ := s.Next(NoContext())
+	require.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Free()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("free timeout")
+	}
+}
@@ -0,0 +1,393 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"sync"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/internal/backoff"
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// Supervisor is a service which starts child services and restarts failed ones.
+//
+// Children are started in the order they are added, and are stopped in reverse order.
+// A child fails when it exits with a non-OK status, children which exit with OK are not restarted.
+// When the number of restarts within a period exceeds the max restarts, the supervisor stops
+// all children and fails with the last child status.
+//
+// Example:
+//
+//	sup := async.NewSupervisor(async.SupervisorOptions{
+//		Strategy:    async.RestartOneForAll,
+//		MaxRestarts: 5,
+//		Period:      time.Minute,
+//	})
+//	sup.Add("server", server)
+//	sup.Add("worker", worker)
+//
+//	sup.Start()
+//	defer async.StopWait(sup)
+type Supervisor interface {
+	Service
+
+	// Add adds a child service, panics if the supervisor is started.
+	Add(name string, s Service) SupervisorChild
+
+	// Children returns the children in the order they were added.
+	Children() []SupervisorChild
+}
+
+// SupervisorChild is a supervised child service.
+type SupervisorChild interface {
+	// Name returns the child name.
+	Name() string
+
+	// Service returns the child service.
+	Service() Service
+
+	// Restarts returns the number of child restarts.
+	Restarts() int
+
+	// Status returns the last child failure status or none.
+	Status() status.Status
+
+	// Flags
+
+	// Running indicates that the child service routine is running.
+	Running() Flag
+
+	// Restarting indicates that the child is stopped and awaits a restart.
+	Restarting() Flag
+}
+
+// SupervisorOptions specifies the supervisor options.
+type SupervisorOptions struct {
+	// Strategy is the restart strategy.
+	Strategy RestartStrategy
+
+	// MaxRestarts is the max number of restarts within the period, zero means 3.
+	MaxRestarts int
+
+	// Period is the restart intensity period, zero means 5 seconds.
+	Period time.Duration
+
+	// MinDelay and MaxDelay are the restart backoff delays, zeros mean the backoff defaults.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+
+	// Backoff returns a restart delay, nil means the default exponential backoff.
+	// The attempt is the number of restarts within the period, retry.DelayOpts can be used here.
+	Backoff func(attempt int, minDelay, maxDelay time.Duration) time.Duration
+}
+
+// RestartStrategy specifies which children the supervisor restarts when a child fails.
+type RestartStrategy int
+
+const (
+	// RestartOneForOne restarts only the failed child.
+	RestartOneForOne RestartStrategy = iota
+
+	// RestartOneForAll stops all children and restarts them.
+	RestartOneForAll
+
+	// RestartRestForOne stops the failed child and the children added after it,
+	// and restarts them.
+	RestartRestForOne
+)
+
+// NewSupervisor returns a new stopped supervisor.
+func NewSupervisor(opts SupervisorOptions) Supervisor {
+	return newSupervisor(opts)
+}
+
+// internal
+
+var (
+	_ Supervisor      = (*supervisor)(nil)
+	_ SupervisorChild = (*supervisorChild)(nil)
+)
+
+type supervisor struct {
+	*service
+	opts SupervisorOptions
+
+	mu       sync.Mutex
+	started  bool
+	children []*supervisorChild
+
+	// run state, accessed only by the supervisor routine
+	restarts []time.Time
+	exits    chan supervisorExit
+}
+
+type supervisorExit struct {
+	child *supervisorChild
+	gen   int
+}
+
+func newSupervisor(opts SupervisorOptions) *supervisor {
+	if opts.MaxRestarts <= 0 {
+		opts.MaxRestarts = 3
+	}
+	if opts.Period <= 0 {
+		opts.Period = 5 * time.Second
+	}
+	if opts.Backoff == nil {
+		opts.Backoff = backoff.Delay
+	}
+
+	s := &supervisor{
+		opts:  opts,
+		exits: make(chan supervisorExit),
+	}
+	s.service = newService(s.run)
+	return s
+}
+
+// Add adds a child service, panics if the supervisor is started.
+func (s *supervisor) Add(name string, svc Service) SupervisorChild {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		panic("cannot add child to started supervisor")
+	}
+
+	c := newSupervisorChild(name, svc)
+	s.children = append(s.children, c)
+	return c
+}
+
+// Children returns the children in the order they were added.
+func (s *supervisor) Children() []SupervisorChild {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]SupervisorChild, 0, len(s.children))
+	for _, c := range s.children {
+		result = append(result, c)
+	}
+	return result
+}
+
+// private
+
+func (s *supervisor) run(ctx Context) status.Status {
+	s.mu.Lock()
+	s.started = true
+	children := s.children
+	s.mu.Unlock()
+
+	s.restarts = s.restarts[:0]
+	defer func() {
+		s.stopChildren(children)
+
+		for _, c := range children {
+			c.restarting.Unset()
+		}
+	}()
+
+	// Start children
+	for _, c := range children {
+		s.startChild(ctx, c)
+	}
+
+	// Supervise children
+	for {
+		var exit supervisorExit
+		select {
+		case <-ctx.Wait():
+			return ctx.Status()
+		case exit = <-s.exits:
+		}
+
+		// Skip stale or successful exits
+		c := exit.child
+		if exit.gen != c.gen {
+			continue
+		}
+		st := c.svc.Status()
+		if st.OK() {
+			continue
+		}
+
+		if st := s.restart(ctx, children, c, st); !st.OK() {
+			return st
+		}
+	}
+}
+
+// restart restarts the failed child and other children depending on the strategy.
+func (s *supervisor) restart(ctx Context, children []*supervisorChild, failed *supervisorChild,
+	st status.Status) status.Status {
+	failed.setStatus(st)
+
+	// Check intensity
+	now := time.Now()
+	i := 0
+	for ; i < len(s.restarts); i++ {
+		if now.Sub(s.restarts[i]) < s.opts.Period {
+			break
+		}
+	}
+	s.restarts = append(s.restarts[:0], s.restarts[i:]...)
+	if len(s.restarts) >= s.opts.MaxRestarts {
+		return st.WrapTextf("supervisor max restarts exceeded, child %q failed", failed.name)
+	}
+
+	attempt := len(s.restarts)
+	s.restarts = append(s.restarts, now)
+
+	// Select children
+	var targets []*supervisorChild
+	switch s.opts.Strategy {
+	case RestartOneForAll:
+		targets = children
+	case RestartRestForOne:
+		for i, c := range children {
+			if c == failed {
+				targets = children[i:]
+				break
+			}
+		}
+	default:
+		targets = []*supervisorChild{failed}
+	}
+
+	// Stop children
+	for _, c := range targets {
+		c.restarting.Set()
+	}
+	s.stopChildren(targets)
+
+	// Await backoff
+	delay := s.opts.Backoff(attempt, s.opts.MinDelay, s.opts.MaxDelay)
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Wait():
+			return ctx.Status()
+		}
+	}
+
+	// Restart children
+	for _, c := range targets {
+		c.incRestarts()
+		c.restarting.Unset()
+		s.startChild(ctx, c)
+	}
+	return status.OK
+}
+
+// startChild starts a child and watches its exit.
+func (s *supervisor) startChild(ctx Context, c *supervisorChild) {
+	c.svc.Start()
+
+	wait := c.svc.Wait()
+	exit := supervisorExit{child: c, gen: c.gen}
+
+	go func() {
+		select {
+		case <-wait:
+		case <-ctx.Wait():
+			return
+		}
+
+		select {
+		case s.exits <- exit:
+		case <-ctx.Wait():
+		}
+	}()
+}
+
+// stopChildren stops children in reverse order, and invalidates their exits.
+func (s *supervisor) stopChildren(children []*supervisorChild) {
+	for i := len(children) - 1; i >= 0; i-- {
+		c := children[i]
+		c.gen++
+		<-c.svc.Stop()
+	}
+}
+
+// child
+
+type supervisorChild struct {
+	name       string
+	svc        Service
+	restarting MutFlag
+	gen        int // start generation, accessed only by the supervisor routine
+
+	mu       sync.Mutex
+	restarts int
+	status   status.Status
+}
+
+func newSupervisorChild(name string, svc Service) *supervisorChild {
+	return &supervisorChild{
+		name:       name,
+		svc:        svc,
+		restarting: UnsetFlag(),
+		status:     status.None,
+	}
+}
+
+// Name returns the child name.
+func (c *supervisorChild) Name() string {
+	return c.name
+}
+
+// Service returns the child service.
+func (c *supervisorChild) Service() Service {
+	return c.svc
+}
+
+// Restarts returns the number of child restarts.
+func (c *supervisorChild) Restarts() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.restarts
+}
+
+// Status returns the last child failure status or none.
+func (c *supervisorChild) Status() status.Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.status
+}
+
+// Flags
+
+// Running indicates that the child service routine is running.
+func (c *supervisorChild) Running() Flag {
+	return c.svc.Running()
+}
+
+// Restarting indicates that the child is stopped and awaits a restart.
+func (c *supervisorChild) Restarting() Flag {
+	return c.restarting
+}
+
+// private
+
+func (c *supervisorChild) incRestarts() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.restarts++
+}
+
+func (c *supervisorChild) setStatus(st status.Status) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.status = st
+}
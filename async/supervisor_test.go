@@ -0,0 +1,207 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSupervised struct {
+	Service
+	starts atomic.Int32
+	fail   chan status.Status
+}
+
+func newTestSupervised() *testSupervised {
+	s := &testSupervised{fail: make(chan status.Status, 1)}
+	s.Service = NewService(func(ctx Context) status.Status {
+		s.starts.Add(1)
+
+		select {
+		case st := <-s.fail:
+			return st
+		case <-ctx.Wait():
+			return ctx.Status()
+		}
+	})
+	return s
+}
+
+func testSupervisor(opts SupervisorOptions) *supervisor {
+	if opts.Backoff == nil {
+		opts.Backoff = func(int, time.Duration, time.Duration) time.Duration { return 0 }
+	}
+	return newSupervisor(opts)
+}
+
+func testStarts(t *testing.T, s *testSupervised, n int32) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		return s.starts.Load() == n && s.Running().IsSet()
+	}, time.Second, time.Millisecond)
+}
+
+// Start
+
+func TestSupervisor_Start__should_start_children(t *testing.T) {
+	s := testSupervisor(SupervisorOptions{})
+	c0 := newTestSupervised()
+	c1 := newTestSupervised()
+	s.Add("c0", c0)
+	s.Add("c1", c1)
+
+	s.Start()
+	testStarts(t, c0, 1)
+	testStarts(t, c1, 1)
+
+	<-s.Stop()
+	assert.False(t, c0.Running().IsSet())
+	assert.False(t, c1.Running().IsSet())
+	assert.True(t, c0.Stopped().IsSet())
+	assert.True(t, c1.Stopped().IsSet())
+}
+
+func TestSupervisor_Start__should_not_restart_child_exited_with_ok(t *testing.T) {
+	s := testSupervisor(SupervisorOptions{})
+	c0 := newTestSupervised()
+	child := s.Add("c0", c0)
+
+	s.Start()
+	defer s.Stop()
+	testStarts(t, c0, 1)
+
+	c0.fail <- status.OK
+	<-c0.Wait()
+
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, int32(1), c0.starts.Load())
+	assert.Equal(t, 0, child.Restarts())
+}
+
+// Strategy
+
+func TestSupervisor__should_restart_failed_child_one_for_one(t *testing.T) {
+	s := testSupervisor(SupervisorOptions{Strategy: RestartOneForOne})
+	c0 := newTestSupervised()
+	c1 := newTestSupervised()
+	child := s.Add("c0", c0)
+	s.Add("c1", c1)
+
+	s.Start()
+	defer s.Stop()
+	testStarts(t, c0, 1)
+	testStarts(t, c1, 1)
+
+	c0.fail <- status.Error("test")
+	testStarts(t, c0, 2)
+
+	assert.Equal(t, int32(1), c1.starts.Load())
+	assert.Equal(t, 1, child.Restarts())
+	assert.Equal(t, status.Error("test"), child.Status())
+}
+
+func TestSupervisor__should_restart_all_children_one_for_all(t *testing.T) {
+	s := testSupervisor(SupervisorOptions{Strategy: RestartOneForAll})
+	c0 := newTestSupervised()
+	c1 := newTestSupervised()
+	c2 := newTestSupervised()
+	s.Add("c0", c0)
+	s.Add("c1", c1)
+	s.Add("c2", c2)
+
+	s.Start()
+	defer s.Stop()
+	testStarts(t, c0, 1)
+	testStarts(t, c1, 1)
+	testStarts(t, c2, 1)
+
+	c1.fail <- status.Error("test")
+	testStarts(t, c0, 2)
+	testStarts(t, c1, 2)
+	testStarts(t, c2, 2)
+}
+
+func TestSupervisor__should_restart_rest_children_rest_for_one(t *testing.T) {
+	s := testSupervisor(SupervisorOptions{Strategy: RestartRestForOne})
+	c0 := newTestSupervised()
+	c1 := newTestSupervised()
+	c2 := newTestSupervised()
+	s.Add("c0", c0)
+	s.Add("c1", c1)
+	s.Add("c2", c2)
+
+	s.Start()
+	defer s.Stop()
+	testStarts(t, c0, 1)
+	testStarts(t, c1, 1)
+	testStarts(t, c2, 1)
+
+	c1.fail <- status.Error("test")
+	testStarts(t, c1, 2)
+	testStarts(t, c2, 2)
+	assert.Equal(t, int32(1), c0.starts.Load())
+}
+
+// Intensity
+
+func TestSupervisor__should_fail_when_max_restarts_exceeded(t *testing.T) {
+	s := testSupervisor(SupervisorOptions{MaxRestarts: 2, Period: time.Minute})
+	c0 := newTestSupervised()
+	c1 := newTestSupervised()
+	s.Add("c0", c0)
+	s.Add("c1", c1)
+
+	s.Start()
+	defer s.Stop()
+
+	for i := int32(1); i <= 3; i++ {
+		testStarts(t, c0, i)
+		c0.fail <- status.Error("test")
+	}
+
+	select {
+	case <-s.Wait():
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+
+	st := s.Status()
+	assert.Equal(t, status.CodeError, st.Code)
+	assert.Contains(t, st.Message, "max restarts exceeded")
+	assert.False(t, c1.Running().IsSet())
+}
+
+// Backoff
+
+func TestSupervisor__should_set_restarting_during_backoff(t *testing.T) {
+	delay := make(chan struct{})
+	s := testSupervisor(SupervisorOptions{
+		Backoff: func(int, time.Duration, time.Duration) time.Duration {
+			close(delay)
+			return 50 * time.Millisecond
+		},
+	})
+	c0 := newTestSupervised()
+	child := s.Add("c0", c0)
+
+	s.Start()
+	defer s.Stop()
+	testStarts(t, c0, 1)
+
+	c0.fail <- status.Error("test")
+	<-delay
+	assert.True(t, child.Restarting().IsSet())
+	assert.False(t, child.Running().IsSet())
+
+	testStarts(t, c0, 2)
+	assert.False(t, child.Restarting().IsSet())
+}
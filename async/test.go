@@ -0,0 +1,69 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"strings"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/tests"
+)
+
+// TestRoutineLeakTimeout is the max time to await routines when a test finishes.
+var TestRoutineLeakTimeout = time.Second
+
+// TestRoutineLeaks returns a context owned by the test, and fails the test if routines started
+// with the context are still running when the test finishes. The routines are awaited for
+// the leak timeout first.
+//
+// The context is never cancelled. Routines are tracked when started with the context
+// or with a context of a tracked routine, i.e. via [RunContext] or [RoutineOptions.Parent],
+// so parallel tests do not affect each other.
+//
+// Usage:
+//
+//	func TestServer(t *testing.T) {
+//		ctx := async.TestRoutineLeaks(t)
+//		r := async.RunVoidContext(ctx, server.Run)
+//		...
+//	}
+func TestRoutineLeaks(t tests.T) Context {
+	scope := routineScopes.Add(1)
+	info := &routineInfo{scope: scope}
+
+	t.Cleanup(func() {
+		deadline := time.Now().Add(TestRoutineLeakTimeout)
+
+		for {
+			leaked := testLeakedRoutines(scope)
+			if len(leaked) == 0 {
+				return
+			}
+
+			if time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+				continue
+			}
+
+			b := &strings.Builder{}
+			b.WriteString("routines leaked: ")
+			writeRoutines(b, leaked)
+
+			t.Helper()
+			t.Error(b.String())
+			return
+		}
+	})
+
+	return WithValue(NoContext(), routineKey{}, info)
+}
+
+// private
+
+func testLeakedRoutines(scope uint64) []RoutineInfo {
+	return routines.list(func(info *routineInfo) bool {
+		return info.scope == scope
+	})
+}
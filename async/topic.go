@@ -0,0 +1,413 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/basecomplextech/baselibrary/collect/chans"
+	"github.com/basecomplextech/baselibrary/collect/slices2"
+	"github.com/basecomplextech/baselibrary/opt"
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+// Topic is an in-process publish/subscribe topic, each subscriber has its own bounded queue.
+//
+// Publish delivers a value to all current subscribers, a full subscriber queue is handled
+// by the subscriber overflow policy. Concurrent publishes are serialized, so subscribers
+// receive values in the same order.
+//
+// Example:
+//
+//	topic := async.NewTopic[Config](async.TopicOptions{Replay: true})
+//
+//	sub := topic.Subscribe(async.SubscribeOptions{Overflow: async.OverflowDropOldest})
+//	defer sub.Free()
+//
+//	for {
+//		config, st := sub.PollContext(ctx)
+//		if !st.OK() {
+//			return st
+//		}
+//		apply(config)
+//	}
+type Topic[T any] interface {
+	// Publish sends a value to all subscribers, blocks while subscribers with the block policy
+	// have full queues. The method returns a closed status if the topic is closed, or a context
+	// status if cancelled, in this case the value may be delivered only to some subscribers.
+	Publish(ctx Context, v T) status.Status
+
+	// Subscribe adds a new subscriber, the subscriber must be freed to unsubscribe.
+	// The subscriber receives the last published value if the topic replays it.
+	// The method returns an ended subscriber if the topic is closed.
+	Subscribe(opts SubscribeOptions) Subscriber[T]
+
+	// Close closes the topic, subscribers receive their remaining values and then an end status.
+	Close()
+}
+
+// Subscriber is a topic subscriber with a bounded queue, and also a stream of values.
+type Subscriber[T any] interface {
+	Stream[T]
+
+	// Len returns the number of queued values.
+	Len() int
+
+	// Poll removes a value from the queue, returns false if the queue is empty,
+	// or an end status if the topic is closed, or a closed status if the subscriber is
+	// disconnected or freed, and the queue is empty.
+	Poll() (T, bool, status.Status)
+
+	// PollContext removes a value from the queue, blocks while the queue is empty.
+	// The method returns the Poll end statuses, or a context status if cancelled.
+	PollContext(ctx Context) (T, status.Status)
+
+	// Wait returns a channel which is notified on new values.
+	// The method returns a closed channel if the queue is not empty or ended.
+	Wait() <-chan struct{}
+}
+
+// TopicOptions specifies the topic options.
+type TopicOptions struct {
+	// Replay sends the last published value to new subscribers.
+	Replay bool
+}
+
+// SubscribeOptions specifies the subscriber options.
+type SubscribeOptions struct {
+	// QueueSize is the max number of queued values, zero means 16.
+	QueueSize int
+
+	// Overflow specifies how a full queue is handled.
+	Overflow OverflowPolicy
+}
+
+// OverflowPolicy specifies how a topic handles a full subscriber queue.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks the publisher until the subscriber polls a value.
+	OverflowBlock OverflowPolicy = iota
+
+	// OverflowDropOldest drops the oldest queued value.
+	OverflowDropOldest
+
+	// OverflowDropNewest drops the published value.
+	OverflowDropNewest
+
+	// OverflowDisconnect disconnects the subscriber, the subscriber receives its remaining
+	// values and then a closed status.
+	OverflowDisconnect
+)
+
+// NewTopic returns a new topic.
+func NewTopic[T any](opts TopicOptions) Topic[T] {
+	return newTopic[T](opts)
+}
+
+// internal
+
+var (
+	_ Topic[int]      = (*topic[int])(nil)
+	_ Subscriber[int] = (*subscriber[int])(nil)
+)
+
+const topicQueueSize = 16
+
+type topic[T any] struct {
+	opts  TopicOptions
+	pubMu sync.Mutex // serializes publishes
+
+	mu     sync.Mutex
+	subs   []*subscriber[T]
+	last   opt.Opt[T]
+	closed bool
+}
+
+func newTopic[T any](opts TopicOptions) *topic[T] {
+	return &topic[T]{opts: opts}
+}
+
+// Publish sends a value to all subscribers, blocks while subscribers with the block policy
+// have full queues. The method returns a closed status if the topic is closed, or a context
+// status if cancelled, in this case the value may be delivered only to some subscribers.
+func (t *topic[T]) Publish(ctx Context, v T) status.Status {
+	t.pubMu.Lock()
+	defer t.pubMu.Unlock()
+
+	// Get subscribers
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return status.Closedf("topic is closed")
+	}
+	if t.opts.Replay {
+		t.last = opt.New(v)
+	}
+	subs := slices.Clone(t.subs)
+	t.mu.Unlock()
+
+	// Push value
+	for _, s := range subs {
+		if st := s.push(ctx, v); !st.OK() {
+			return st
+		}
+	}
+	return status.OK
+}
+
+// Subscribe adds a new subscriber, the subscriber must be freed to unsubscribe.
+// The subscriber receives the last published value if the topic replays it.
+// The method returns an ended subscriber if the topic is closed.
+func (t *topic[T]) Subscribe(opts SubscribeOptions) Subscriber[T] {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = topicQueueSize
+	}
+	s := newSubscriber(t, opts)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		s.close(status.End)
+		return s
+	}
+
+	if last, ok := t.last.Unwrap(); ok {
+		s.list = append(s.list, last)
+	}
+	t.subs = append(t.subs, s)
+	return s
+}
+
+// Close closes the topic, subscribers receive their remaining values and then an end status.
+func (t *topic[T]) Close() {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return
+	}
+
+	subs := t.subs
+	t.subs = nil
+	t.last = opt.None[T]()
+	t.closed = true
+	t.mu.Unlock()
+
+	for _, s := range subs {
+		s.close(status.End)
+	}
+}
+
+// private
+
+func (t *topic[T]) remove(s *subscriber[T]) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.subs = slices2.Remove(t.subs, s)
+}
+
+// subscriber
+
+type subscriber[T any] struct {
+	topic *topic[T]
+	opts  SubscribeOptions
+
+	mu        sync.Mutex
+	list      []T
+	end       status.Status // end status, none while connected
+	readWait  chan struct{}
+	writeWait chan struct{}
+}
+
+func newSubscriber[T any](t *topic[T], opts SubscribeOptions) *subscriber[T] {
+	return &subscriber[T]{
+		topic:     t,
+		opts:      opts,
+		list:      make([]T, 0, opts.QueueSize),
+		readWait:  make(chan struct{}, 1),
+		writeWait: make(chan struct{}, 1),
+	}
+}
+
+// Len returns the number of queued values.
+func (s *subscriber[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.list)
+}
+
+// Next returns the next value, blocks while the queue is empty, or returns false
+// and an end status.
+func (s *subscriber[T]) Next(ctx Context) (T, bool, status.Status) {
+	v, st := s.PollContext(ctx)
+	if !st.OK() {
+		return v, false, st
+	}
+	return v, true, status.OK
+}
+
+// Poll removes a value from the queue, returns false if the queue is empty,
+// or an end status if the topic is closed, or a closed status if the subscriber is
+// disconnected or freed, and the queue is empty.
+func (s *subscriber[T]) Poll() (v T, ok bool, st status.Status) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.list) == 0 {
+		if s.end.Code != status.CodeNone {
+			return v, false, s.end
+		}
+		return v, false, status.OK
+	}
+
+	// Get value, shift remaining left
+	v = s.list[0]
+	s.list = slices2.ShiftLeft(s.list, 1)
+	s.notifyWrite()
+
+	// Pass notification to next reader
+	if len(s.list) > 0 {
+		s.notifyRead()
+	}
+	return v, true, status.OK
+}
+
+// PollContext removes a value from the queue, blocks while the queue is empty.
+// The method returns the Poll end statuses, or a context status if cancelled.
+func (s *subscriber[T]) PollContext(ctx Context) (v T, st status.Status) {
+	for {
+		v, ok, st := s.Poll()
+		switch {
+		case !st.OK():
+			return v, st
+		case ok:
+			return v, status.OK
+		}
+
+		select {
+		case <-s.Wait():
+		case <-ctx.Wait():
+			return v, ctx.Status()
+		}
+	}
+}
+
+// Wait returns a channel which is notified on new values.
+// The method returns a closed channel if the queue is not empty or ended.
+func (s *subscriber[T]) Wait() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.list) > 0 || s.end.Code != status.CodeNone {
+		return chans.Closed()
+	}
+	return s.readWait
+}
+
+// Free unsubscribes the subscriber, and clears its queue.
+func (s *subscriber[T]) Free() {
+	s.topic.remove(s)
+	s.close(status.Closedf("subscriber is freed"))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.list = slices2.Truncate(s.list)
+}
+
+// private
+
+// push adds a value to the queue, handles the overflow policy when the queue is full.
+// The method returns a context status if cancelled while blocked.
+func (s *subscriber[T]) push(ctx Context, v T) status.Status {
+	for {
+		s.mu.Lock()
+		switch {
+		case s.end.Code != status.CodeNone:
+			s.mu.Unlock()
+			return status.OK
+
+		case len(s.list) < s.opts.QueueSize:
+			s.list = append(s.list, v)
+			s.notifyRead()
+			s.mu.Unlock()
+			return status.OK
+		}
+
+		// Handle overflow
+		switch s.opts.Overflow {
+		case OverflowDropOldest:
+			s.list = slices2.ShiftLeft(s.list, 1)
+			s.list = append(s.list, v)
+			s.mu.Unlock()
+			return status.OK
+
+		case OverflowDropNewest:
+			s.mu.Unlock()
+			return status.OK
+
+		case OverflowDisconnect:
+			s._close(status.Closedf("subscriber is disconnected, queue overflow"))
+			s.mu.Unlock()
+
+			s.topic.remove(s)
+			return status.OK
+		}
+
+		// Await poll
+		wait := s.writeWait
+		s.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Wait():
+			return ctx.Status()
+		}
+	}
+}
+
+// close ends the subscriber, wakes up the reader and the publisher.
+func (s *subscriber[T]) close(st status.Status) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s._close(st)
+}
+
+func (s *subscriber[T]) _close(st status.Status) {
+	if s.end.Code != status.CodeNone {
+		return
+	}
+
+	s.end = st
+	close(s.readWait)
+	close(s.writeWait)
+}
+
+func (s *subscriber[T]) notifyRead() {
+	if s.end.Code != status.CodeNone {
+		return
+	}
+
+	select {
+	case s.readWait <- struct{}{}:
+	default:
+	}
+}
+
+func (s *subscriber[T]) notifyWrite() {
+	if s.end.Code != status.CodeNone {
+		return
+	}
+
+	select {
+	case s.writeWait <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package async
+
+import (
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSubscriberPollAll[T any](t *testing.T, s Subscriber[T]) []T {
+	var result []T
+	for {
+		v, ok, st := s.Poll()
+		require.True(t, st.OK())
+		if !ok {
+			return result
+		}
+		result = append(result, v)
+	}
+}
+
+// Publish
+
+func TestTopic_Publish__should_send_value_to_all_subscribers(t *testing.T) {
+	tp := NewTopic[int](TopicOptions{})
+	sub0 := tp.Subscribe(SubscribeOptions{})
+	sub1 := tp.Subscribe(SubscribeOptions{})
+	defer sub0.Free()
+	defer sub1.Free()
+
+	ctx := NoContext()
+	tp.Publish(ctx, 1)
+	tp.Publish(ctx, 2)
+
+	assert.Equal(t, []int{1, 2}, testSubscriberPollAll(t, sub0))
+	assert.Equal(t, []int{1, 2}, testSubscriberPollAll(t, sub1))
+}
+
+func TestTopic_Publish__should_return_closed_when_topic_closed(t *testing.T) {
+	tp := NewTopic[int](TopicOptions{})
+	tp.Close()
+
+	st := tp.Publish(NoContext(), 1)
+	assert.Equal(t, status.CodeClosed, st.Code)
+}
+
+func TestTopic_Publish__should_block_until_subscriber_polls(t *testing.T) {
+	tp := NewTopic[int](TopicOptions{})
+	sub := tp.Subscribe(SubscribeOptions{QueueSize: 1})
+	defer sub.Free()
+
+	ctx := NoContext()
+	tp.Publish(ctx, 1)
+
+	done := make(chan status.Status, 1)
+	go func() {
+		done <- tp.Publish(ctx, 2)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("publish must block")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	v, ok, _ := sub.Poll()
+	require.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	st := <-done
+	require.True(t, st.OK())
+	assert.Equal(t, []int{2}, testSubscriberPollAll(t, sub))
+}
+
+func TestTopic_Publish__should_return_context_status_when_blocked(t *testing.T) {
+	tp := NewTopic[int](TopicOptions{})
+	sub := tp.Subscribe(SubscribeOptions{QueueSize: 1})
+	defer sub.Free()
+
+	tp.Publish(NoContext(), 1)
+
+	ctx := NewContext()
+	defer ctx.Free()
+	ctx.Cancel()
+
+	st := tp.Publish(ctx, 2)
+	assert.Equal(t, status.CodeCancelled, st.Code)
+}
+
+func TestTopic_Publish__should_drop_oldest_value(t *testing.T) {
+	tp := NewTopic[int](TopicOptions{})
+	sub := tp.Subscribe(SubscribeOptions{
+		QueueSize: 2,
+		Overflow:  OverflowDropOldest,
+	})
+	defer sub.Free()
+
+	ctx := NoContext()
+	for i := range 4 {
+		tp.Publish(ctx, i)
+	}
+
+	assert.Equal(t, []int{2, 3}, testSubscriberPollAll(t, sub))
+}
+
+func TestTopic_Publish__should_drop_newest_value(t *testing.T) {
+	tp := NewTopic[int](TopicOptions{})
+	sub := tp.Subscribe(SubscribeOptions{
+		QueueSize: 2,
+		Overflow:  OverflowDropNewest,
+	})
+	defer sub.Free()
+
+	ctx := NoContext()
+	for i := range 4 {
+		tp.Publish(ctx, i)
+	}
+
+	assert.Equal(t, []int{0, 1}, testSubscriberPollAll(t, sub))
+}
+
+func TestTopic_Publish__should_disconnect_subscriber_on_overflow(t *testing.T) {
+	tp := NewTopic[int](TopicOptions{})
+	sub := tp.Subscribe(SubscribeOptions{
+		QueueSize: 1,
+		Overflow:  OverflowDisconnect,
+	})
+	defer sub.Free()
+
+	ctx := NoContext()
+	tp.Publish(ctx, 1)
+	tp.Publish(ctx, 2)
+	tp.Publish(ctx, 3)
+
+	v, ok, st := sub.Poll()
+	require.True(t, ok)
+	require.True(t, st.OK())
+	assert.Equal(t, 1, v)
+
+	_, ok, st = sub.Poll()
+	assert.False(t, ok)
+	assert.Equal(t, status.CodeClosed, st.Code)
+	assert.Empty(t, tp.(*topic[int]).subs)
+}
+
+// Subscribe
+
+func TestTopic_Subscribe__should_replay_last_value(t *testing.T) {
+	tp := NewTopic[int](TopicOptions{Replay: true})
+
+	ctx := NoContext()
+	tp.Publish(ctx, 1)
+	tp.Publish(ctx, 2)
+
+	sub := tp.Subscribe(SubscribeOptions{})
+	defer sub.Free()
+
+	assert.Equal(t, []int{2}, testSubscriberPollAll(t, sub))
+}
+
+func TestTopic_Subscribe__should_not_replay_without_option(t *testing.T) {
+	tp := NewTopic[int](TopicOptions{})
+	tp.Publish(NoContext(), 1)
+
+	sub := tp.Subscribe(SubscribeOptions{})
+	defer sub.Free()
+
+	assert.Equal(t, 0, sub.Len())
+}
+
+// Close
+
+func TestTopic_Close__should_end_subscribers_after_remaining_values(t *testing.T) {
+	tp := NewTopic[int](TopicOptions{})
+	sub := tp.Subscribe(SubscribeOptions{})
+	defer sub.Free()
+
+	tp.Publish(NoContext(), 1)
+	tp.Close()
+
+	values, st := testStreamAll[int](t, sub)
+	assert.Equal(t, status.End, st)
+	assert.Equal(t, []int{1}, values)
+}
+
+// Subscriber
+
+func TestSubscriber_Wait__should_notify_on_publish(t *testing.T) {
+	tp := NewTopic[int](TopicOptions{})
+	sub := tp.Subscribe(SubscribeOptions{})
+	defer sub.Free()
+
+	wait := sub.Wait()
+	select {
+	case <-wait:
+		t.Fatal("wait must block")
+	default:
+	}
+
+	tp.Publish(NoContext(), 1)
+
+	select {
+	case <-wait:
+	case <-time.After(time.Second):
+		t.Fatal("wait timeout")
+	}
+}
+
+func TestSubscriber_Free__should_unsubscribe(t *testing.T) {
+	tp := NewTopic[int](TopicOptions{})
+	sub := tp.Subscribe(SubscribeOptions{})
+	sub.Free()
+
+	assert.Empty(t, tp.(*topic[int]).subs)
+
+	_, ok, st := sub.Poll()
+	assert.False(t, ok)
+	assert.Equal(t, status.CodeClosed, st.Code)
+}
+
+func TestSubscriber_Free__should_unblock_publisher(t *testing.T) {
+	tp := NewTopic[int](TopicOptions{})
+	sub := tp.Subscribe(SubscribeOptions{QueueSize: 1})
+
+	ctx := NoContext()
+	tp.Publish(ctx, 1)
+
+	done := make(chan status.Status, 1)
+	go func() {
+		done <- tp.Publish(ctx, 2)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	sub.Free()
+
+	select {
+	case st := <-done:
+		assert.True(t, st.OK())
+	case <-time.After(time.Second):
+		t.Fatal("publish timeout")
+	}
+}
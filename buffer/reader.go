@@ -0,0 +1,338 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package buffer
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/basecomplextech/baselibrary/encoding/compactint"
+	"github.com/basecomplextech/baselibrary/encoding/rvarint"
+	"github.com/basecomplextech/baselibrary/status"
+)
+
+var _ io.ByteReader = (*Reader)(nil)
+
+// Reader decodes binary values from a byte slice and tracks its position.
+//
+// Forward methods read values from the start of the unread bytes,
+// reverse methods read values from the end. All methods return a parse error
+// status on short or malformed input, and do not advance the reader in this case.
+//
+// Usage:
+//
+//	r := buffer.NewReader(b)
+//	size, st := r.ReadUint32BE()
+//	if !st.OK() {
+//		return st
+//	}
+//	data, st := r.ReadBytes(int(size))
+type Reader struct {
+	buf   []byte
+	start int // forward read position
+	end   int // reverse read position
+}
+
+// NewReader returns a new reader of a byte slice.
+func NewReader(b []byte) *Reader {
+	return &Reader{
+		buf: b,
+		end: len(b),
+	}
+}
+
+// Reset resets the reader to read from a byte slice.
+func (r *Reader) Reset(b []byte) {
+	*r = Reader{
+		buf: b,
+		end: len(b),
+	}
+}
+
+// Len returns the number of unread bytes.
+func (r *Reader) Len() int {
+	return r.end - r.start
+}
+
+// Pos returns the forward read position, i.e. the number of bytes read from the start.
+func (r *Reader) Pos() int {
+	return r.start
+}
+
+// End returns the reverse read position, i.e. the end of the unread bytes.
+func (r *Reader) End() int {
+	return r.end
+}
+
+// Bytes returns the unread bytes, the slice references the underlying buffer.
+func (r *Reader) Bytes() []byte {
+	return r.buf[r.start:r.end]
+}
+
+// Skip skips the next n bytes.
+func (r *Reader) Skip(n int) status.Status {
+	if n < 0 || n > r.Len() {
+		return errShortInput
+	}
+
+	r.start += n
+	return status.OK
+}
+
+// Bytes
+
+// ReadByte reads and returns the next byte, implements io.ByteReader.
+// The error is a parse error status on short input.
+func (r *Reader) ReadByte() (byte, error) {
+	v, st := r.ReadUint8()
+	return v, st.ToError()
+}
+
+// ReadBytes reads the next n bytes, the slice references the underlying buffer.
+func (r *Reader) ReadBytes(n int) ([]byte, status.Status) {
+	if n < 0 || n > r.Len() {
+		return nil, errShortInput
+	}
+
+	b := r.buf[r.start : r.start+n : r.start+n]
+	r.start += n
+	return b, status.OK
+}
+
+// ReadString reads the next n bytes and returns them as a new string.
+func (r *Reader) ReadString(n int) (string, status.Status) {
+	b, st := r.ReadBytes(n)
+	if !st.OK() {
+		return "", st
+	}
+	return string(b), status.OK
+}
+
+// Fixed
+
+// ReadUint8 reads the next uint8.
+func (r *Reader) ReadUint8() (uint8, status.Status) {
+	if r.Len() < 1 {
+		return 0, errShortInput
+	}
+
+	v := r.buf[r.start]
+	r.start++
+	return v, status.OK
+}
+
+// ReadUint16BE reads the next big-endian uint16.
+func (r *Reader) ReadUint16BE() (uint16, status.Status) {
+	b, st := r.ReadBytes(2)
+	if !st.OK() {
+		return 0, st
+	}
+	return binary.BigEndian.Uint16(b), status.OK
+}
+
+// ReadUint32BE reads the next big-endian uint32.
+func (r *Reader) ReadUint32BE() (uint32, status.Status) {
+	b, st := r.ReadBytes(4)
+	if !st.OK() {
+		return 0, st
+	}
+	return binary.BigEndian.Uint32(b), status.OK
+}
+
+// ReadUint64BE reads the next big-endian uint64.
+func (r *Reader) ReadUint64BE() (uint64, status.Status) {
+	b, st := r.ReadBytes(8)
+	if !st.OK() {
+		return 0, st
+	}
+	return binary.BigEndian.Uint64(b), status.OK
+}
+
+// ReadUint16LE reads the next little-endian uint16.
+func (r *Reader) ReadUint16LE() (uint16, status.Status) {
+	b, st := r.ReadBytes(2)
+	if !st.OK() {
+		return 0, st
+	}
+	return binary.LittleEndian.Uint16(b), status.OK
+}
+
+// ReadUint32LE reads the next little-endian uint32.
+func (r *Reader) ReadUint32LE() (uint32, status.Status) {
+	b, st := r.ReadBytes(4)
+	if !st.OK() {
+		return 0, st
+	}
+	return binary.LittleEndian.Uint32(b), status.OK
+}
+
+// ReadUint64LE reads the next little-endian uint64.
+func (r *Reader) ReadUint64LE() (uint64, status.Status) {
+	b, st := r.ReadBytes(8)
+	if !st.OK() {
+		return 0, st
+	}
+	return binary.LittleEndian.Uint64(b), status.OK
+}
+
+// Compact
+
+// ReadCompactInt32 reads the next compactint-encoded int32.
+func (r *Reader) ReadCompactInt32() (int32, status.Status) {
+	v, n := compactint.Int32(r.Bytes())
+	if n <= 0 {
+		return 0, compactError(n)
+	}
+
+	r.start += n
+	return v, status.OK
+}
+
+// ReadCompactInt64 reads the next compactint-encoded int64.
+func (r *Reader) ReadCompactInt64() (int64, status.Status) {
+	v, n := compactint.Int64(r.Bytes())
+	if n <= 0 {
+		return 0, compactError(n)
+	}
+
+	r.start += n
+	return v, status.OK
+}
+
+// ReadCompactUint32 reads the next compactint-encoded uint32.
+func (r *Reader) ReadCompactUint32() (uint32, status.Status) {
+	v, n := compactint.Uint32(r.Bytes())
+	if n <= 0 {
+		return 0, compactError(n)
+	}
+
+	r.start += n
+	return v, status.OK
+}
+
+// ReadCompactUint64 reads the next compactint-encoded uint64.
+func (r *Reader) ReadCompactUint64() (uint64, status.Status) {
+	v, n := compactint.Uint64(r.Bytes())
+	if n <= 0 {
+		return 0, compactError(n)
+	}
+
+	r.start += n
+	return v, status.OK
+}
+
+// Reverse
+
+// ReadReverseBytes reads n bytes from the end, the slice references the underlying buffer.
+func (r *Reader) ReadReverseBytes(n int) ([]byte, status.Status) {
+	if n < 0 || n > r.Len() {
+		return nil, errShortInput
+	}
+
+	b := r.buf[r.end-n : r.end : r.end]
+	r.end -= n
+	return b, status.OK
+}
+
+// ReadReverseInt32 reads a reverse compactint-encoded int32 from the end.
+func (r *Reader) ReadReverseInt32() (int32, status.Status) {
+	v, n := compactint.ReverseInt32(r.Bytes())
+	if n <= 0 {
+		return 0, compactError(n)
+	}
+
+	r.end -= n
+	return v, status.OK
+}
+
+// ReadReverseInt64 reads a reverse compactint-encoded int64 from the end.
+func (r *Reader) ReadReverseInt64() (int64, status.Status) {
+	v, n := compactint.ReverseInt64(r.Bytes())
+	if n <= 0 {
+		return 0, compactError(n)
+	}
+
+	r.end -= n
+	return v, status.OK
+}
+
+// ReadReverseUint32 reads a reverse compactint-encoded uint32 from the end.
+func (r *Reader) ReadReverseUint32() (uint32, status.Status) {
+	v, n := compactint.ReverseUint32(r.Bytes())
+	if n <= 0 {
+		return 0, compactError(n)
+	}
+
+	r.end -= n
+	return v, status.OK
+}
+
+// ReadReverseUint64 reads a reverse compactint-encoded uint64 from the end.
+func (r *Reader) ReadReverseUint64() (uint64, status.Status) {
+	v, n := compactint.ReverseUint64(r.Bytes())
+	if n <= 0 {
+		return 0, compactError(n)
+	}
+
+	r.end -= n
+	return v, status.OK
+}
+
+// Rvarint
+
+// ReadRvarint64 reads a reverse varint-encoded int64 from the end.
+func (r *Reader) ReadRvarint64() (int64, status.Status) {
+	v, n := rvarint.Int64(r.Bytes())
+	if n <= 0 {
+		return 0, rvarintError(n)
+	}
+
+	r.end -= n
+	return v, status.OK
+}
+
+// ReadRvarUint32 reads a reverse varint-encoded uint32 from the end.
+func (r *Reader) ReadRvarUint32() (uint32, status.Status) {
+	v, n := rvarint.Uint32(r.Bytes())
+	if n <= 0 {
+		return 0, rvarintError(n)
+	}
+
+	r.end -= n
+	return v, status.OK
+}
+
+// ReadRvarUint64 reads a reverse varint-encoded uint64 from the end.
+func (r *Reader) ReadRvarUint64() (uint64, status.Status) {
+	v, n := rvarint.Uint64(r.Bytes())
+	if n <= 0 {
+		return 0, rvarintError(n)
+	}
+
+	r.end -= n
+	return v, status.OK
+}
+
+// private
+
+var (
+	errShortInput      = status.ParseError("buffer: unexpected end of input")
+	errInvalidCompact  = status.ParseError("buffer: invalid compact int")
+	errRvarintOverflow = status.ParseError("buffer: rvarint overflow")
+)
+
+func compactError(n int) status.Status {
+	if n == 0 {
+		return errShortInput
+	}
+	return errInvalidCompact
+}
+
+func rvarintError(n int) status.Status {
+	if n == 0 {
+		return errShortInput
+	}
+	return errRvarintOverflow
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package buffer
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/encoding/compactint"
+	"github.com/basecomplextech/baselibrary/encoding/rvarint"
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ReadByte
+
+func TestReader_ReadByte__should_read_byte(t *testing.T) {
+	r := NewReader([]byte{1, 2})
+
+	v, err := r.ReadByte()
+	require.NoError(t, err)
+	assert.Equal(t, byte(1), v)
+	assert.Equal(t, 1, r.Pos())
+	assert.Equal(t, 1, r.Len())
+}
+
+func TestReader_ReadByte__should_return_parse_error_when_empty(t *testing.T) {
+	r := NewReader(nil)
+
+	_, err := r.ReadByte()
+	require.Error(t, err)
+
+	st := err.(*status.Err).Status()
+	assert.Equal(t, status.CodeParseError, st.Code)
+}
+
+// ReadBytes
+
+func TestReader_ReadBytes__should_read_bytes(t *testing.T) {
+	r := NewReader([]byte("hello, world"))
+
+	b, st := r.ReadBytes(5)
+	require.True(t, st.OK())
+	assert.Equal(t, []byte("hello"), b)
+
+	s, st := r.ReadString(2)
+	require.True(t, st.OK())
+	assert.Equal(t, ", ", s)
+	assert.Equal(t, []byte("world"), r.Bytes())
+}
+
+func TestReader_ReadBytes__should_return_parse_error_on_short_input(t *testing.T) {
+	r := NewReader([]byte("hello"))
+
+	_, st := r.ReadBytes(6)
+	assert.Equal(t, status.CodeParseError, st.Code)
+	assert.Equal(t, 0, r.Pos())
+}
+
+// ReadUint32BE
+
+func TestReader_ReadUint32BE__should_read_uint32(t *testing.T) {
+	b := binary.BigEndian.AppendUint32(nil, math.MaxUint32-1)
+	b = binary.LittleEndian.AppendUint32(b, 1234)
+	r := NewReader(b)
+
+	v, st := r.ReadUint32BE()
+	require.True(t, st.OK())
+	assert.Equal(t, uint32(math.MaxUint32-1), v)
+
+	v, st = r.ReadUint32LE()
+	require.True(t, st.OK())
+	assert.Equal(t, uint32(1234), v)
+	assert.Equal(t, 0, r.Len())
+}
+
+func TestReader_ReadUint32BE__should_return_parse_error_on_short_input(t *testing.T) {
+	r := NewReader([]byte{1, 2, 3})
+
+	_, st := r.ReadUint32BE()
+	assert.Equal(t, status.CodeParseError, st.Code)
+	assert.Equal(t, 3, r.Len())
+}
+
+// ReadCompactUint64
+
+func TestReader_ReadCompactUint64__should_read_compact_uint64(t *testing.T) {
+	b := make([]byte, compactint.MaxLen64*2)
+	n := compactint.PutUint64(b, math.MaxUint64)
+	n += compactint.PutUint64(b[n:], 1)
+	r := NewReader(b[:n])
+
+	v, st := r.ReadCompactUint64()
+	require.True(t, st.OK())
+	assert.Equal(t, uint64(math.MaxUint64), v)
+
+	v, st = r.ReadCompactUint64()
+	require.True(t, st.OK())
+	assert.Equal(t, uint64(1), v)
+	assert.Equal(t, 0, r.Len())
+}
+
+func TestReader_ReadCompactUint64__should_return_parse_error_on_short_input(t *testing.T) {
+	b := make([]byte, compactint.MaxLen64)
+	n := compactint.PutUint64(b, math.MaxUint64)
+	r := NewReader(b[:n-1])
+
+	_, st := r.ReadCompactUint64()
+	assert.Equal(t, status.CodeParseError, st.Code)
+	assert.Equal(t, 0, r.Pos())
+}
+
+func TestReader_ReadCompactUint32__should_return_parse_error_on_overflow(t *testing.T) {
+	b := make([]byte, compactint.MaxLen64)
+	n := compactint.PutUint64(b, math.MaxUint64)
+	r := NewReader(b[:n])
+
+	_, st := r.ReadCompactUint32()
+	assert.Equal(t, status.CodeParseError, st.Code)
+}
+
+// ReadReverseInt64
+
+func TestReader_ReadReverseInt64__should_read_from_end(t *testing.T) {
+	b := []byte("head")
+	p := make([]byte, compactint.MaxLen64)
+	n := compactint.PutReverseInt64(p, math.MinInt64)
+	b = append(b, p[len(p)-n:]...)
+	r := NewReader(b)
+
+	v, st := r.ReadReverseInt64()
+	require.True(t, st.OK())
+	assert.Equal(t, int64(math.MinInt64), v)
+	assert.Equal(t, []byte("head"), r.Bytes())
+	assert.Equal(t, 4, r.End())
+}
+
+func TestReader_ReadReverseInt64__should_return_parse_error_on_short_input(t *testing.T) {
+	r := NewReader([]byte{0xfe})
+
+	_, st := r.ReadReverseInt64()
+	assert.Equal(t, status.CodeParseError, st.Code)
+	assert.Equal(t, 1, r.End())
+}
+
+func TestReader_ReadReverseInt64__should_not_read_past_forward_position(t *testing.T) {
+	r := NewReader([]byte{1, 2})
+
+	_, st := r.ReadBytes(2)
+	require.True(t, st.OK())
+
+	_, st = r.ReadReverseInt64()
+	assert.Equal(t, status.CodeParseError, st.Code)
+}
+
+// ReadRvarint64
+
+func TestReader_ReadRvarint64__should_read_from_end(t *testing.T) {
+	p := make([]byte, rvarint.MaxLen64)
+	n := rvarint.PutInt64(p, -1234)
+	r := NewReader(p[len(p)-n:])
+
+	v, st := r.ReadRvarint64()
+	require.True(t, st.OK())
+	assert.Equal(t, int64(-1234), v)
+	assert.Equal(t, 0, r.Len())
+}
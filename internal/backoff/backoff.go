@@ -0,0 +1,32 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package backoff provides an exponential backoff shared by the retry and async packages.
+package backoff
+
+import "time"
+
+const (
+	MinDelay = 25 * time.Millisecond
+	MaxDelay = 1 * time.Second
+)
+
+// Delay returns a delay for an attempt, uses an exponential backoff.
+// Zero min and max delays mean the default ones.
+func Delay(attempt int, minDelay, maxDelay time.Duration) time.Duration {
+	if attempt == 0 {
+		return 0
+	}
+
+	if minDelay == 0 {
+		minDelay = MinDelay
+	}
+	if maxDelay == 0 {
+		maxDelay = MaxDelay
+	}
+
+	multi := uint16(1<<attempt - 1)
+	delay := minDelay * time.Duration(multi)
+	return min(delay, maxDelay)
+}
@@ -0,0 +1,24 @@
+// Copyright 2026 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package backoff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelay__should_return_exponential_backoff(t *testing.T) {
+	assert.Equal(t, time.Duration(0), Delay(0, 0, 0))
+	assert.Equal(t, 25*time.Millisecond, Delay(1, 0, 0))
+	assert.Equal(t, 75*time.Millisecond, Delay(2, 0, 0))
+	assert.Equal(t, time.Second, Delay(10, 0, 0))
+}
+
+func TestDelay__should_use_min_and_max_delay(t *testing.T) {
+	assert.Equal(t, 100*time.Millisecond, Delay(1, 100*time.Millisecond, time.Second))
+	assert.Equal(t, 500*time.Millisecond, Delay(5, 100*time.Millisecond, 500*time.Millisecond))
+}
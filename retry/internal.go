@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/basecomplextech/baselibrary/async"
+	"github.com/basecomplextech/baselibrary/internal/backoff"
 	"github.com/basecomplextech/baselibrary/logging"
 	"github.com/basecomplextech/baselibrary/status"
 )
@@ -75,7 +76,7 @@ func (r retrier) handleError(err status.Status, attempt int) status.Status {
 
 func (r retrier) sleep(ctx async.Context, attempt int) status.Status {
 	// Sleep before retry
-	delay := delay(attempt, r.opts.MinDelay, r.opts.MaxDelay)
+	delay := backoff.Delay(attempt, r.opts.MinDelay, r.opts.MaxDelay)
 	timer := time.NewTimer(delay)
 	select {
 	case <-ctx.Wait():